@@ -0,0 +1,50 @@
+{{- $secret := dig "metadata" "annotations" "platform.kubevirt.io/backup-secret" "" .HCO.Object }}
+{{- $provider := dig "metadata" "annotations" "platform.kubevirt.io/backup-provider" "aws" .HCO.Object }}
+{{- $location := splitList "/" (dig "metadata" "annotations" "platform.kubevirt.io/backup-bucket" "" .HCO.Object) }}
+{{- $bucket := first $location }}
+{{- $region := dig "metadata" "annotations" "platform.kubevirt.io/backup-region" "" .HCO.Object }}
+{{- $credentialKey := dig "metadata" "annotations" "platform.kubevirt.io/backup-secret-key" "cloud" .HCO.Object }}
+{{- /* OADP rejects an aws BackupStorageLocation without a region */}}
+{{- $withLocation := and $bucket (or $region (ne $provider "aws")) }}
+apiVersion: oadp.openshift.io/v1alpha1
+kind: DataProtectionApplication
+metadata:
+  name: virt-backup
+  namespace: openshift-adp
+spec:
+  configuration:
+    velero:
+      defaultPlugins:
+        - openshift
+        - kubevirt
+        - csi
+        - {{ $provider | quote }}
+      # Built-in data mover: move CSI snapshot data of VM disks to object storage
+      defaultSnapshotMoveData: true
+{{- if not $withLocation }}
+      # No complete bucket annotations: the user adds BackupStorageLocations directly
+      noDefaultBackupLocation: true
+{{- end }}
+    nodeAgent:
+      enable: true
+      uploaderType: kopia
+{{- if $withLocation }}
+  backupLocations:
+    - name: default
+      velero:
+        provider: {{ $provider | quote }}
+        default: true
+        # User-supplied Secret in openshift-adp, in the provider's credentials file format
+        credential:
+          name: {{ $secret | quote }}
+          key: {{ $credentialKey | quote }}
+{{- with $region }}
+        config:
+          region: {{ . | quote }}
+{{- end }}
+        objectStorage:
+          bucket: {{ $bucket | quote }}
+{{- with rest $location | join "/" }}
+          prefix: {{ . | quote }}
+{{- end }}
+{{- end }}
@@ -17,6 +17,14 @@ spec:
     allowAutoConverge: false
     allowPostCopy: false
     completionTimeoutPerGiB: 150
+{{- /* Wired once migration-network created the NAD, migrations fail on a network that does not exist */}}
+{{- if and (crdExists "network-attachment-definitions.k8s.cni.cncf.io") (crdExists "nodenetworkconfigurationpolicies.nmstate.io") }}
+{{- with .MigrationNetwork }}
+{{- if objectExists "NetworkAttachmentDefinition" (dig "metadata" "namespace" "openshift-cnv" $.HCO.Object) .Name }}
+    network: {{ .Name }}
+{{- end }}
+{{- end }}
+{{- end }}
     parallelMigrationsPerCluster: 5
     parallelOutboundMigrationsPerNode: 2
     progressTimeout: 150
@@ -41,10 +49,46 @@ spec:
       duration: 24h0m0s
       renewBefore: 12h0m0s
 
+{{- if eq (dig "metadata" "annotations" "platform.kubevirt.io/enable-aaq" "" .HCO.Object) "true" }}
+
+  # Application Aware Quota: the HCO deploys and owns the AAQ CR, configured from here
+  # to count VM resources, not virt-launcher overhead
+  enableApplicationAwareQuota: true
+  applicationAwareConfig:
+    vmiCalcConfigName: VirtualResources
+    allowApplicationAwareClusterResourceQuota: false
+{{- end }}
+
   # Resource requirements for virt components
   resourceRequirements:
     vmiCPUAllocationRatio: 10
 
+{{- $pciDevices := .VFIODevices }}
+{{- $usbDevices := .USBHostDevices }}
+{{- if or $pciDevices $usbDevices }}
+
+  # Host devices allowed for passthrough (vfio-devices / usb-devices annotations)
+  permittedHostDevices:
+{{- with $pciDevices }}
+    pciHostDevices:
+{{- range . }}
+      - pciDeviceSelector: {{ .Selector | quote }}
+        resourceName: {{ .ResourceName | quote }}
+{{- end }}
+{{- end }}
+{{- with $usbDevices }}
+    usbHostDevices:
+{{- range . }}
+      - resourceName: {{ .ResourceName | quote }}
+        selectors:
+{{- range .Selectors }}
+          - vendor: {{ .VendorID | quote }}
+            product: {{ .ProductID | quote }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+
   # Uninstall strategy
   uninstallStrategy: BlockUninstallIfWorkloadsExist
 
@@ -1,7 +1,42 @@
+{{- /* Reservations depend on the node topology: each topology gets a custom pool named after it */}}
+{{- range $i, $group := topologyGroups . }}
+{{- if $i }}
+---
+{{- end }}
+{{- with $group.Pool }}
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfigPool
+metadata:
+  name: {{ . }}
+  labels:
+    pools.operator.machineconfiguration.openshift.io/{{ . }}: ""
+spec:
+  machineConfigSelector:
+    matchExpressions:
+      - key: machineconfiguration.openshift.io/role
+        operator: In
+        values:
+          - worker
+{{- with $group.Vendor }}
+          - virt-{{ . }}
+{{- end }}
+          - {{ . }}
+  nodeSelector:
+    matchLabels:
+      node-role.kubernetes.io/worker: ""
+    matchExpressions:
+      - key: kubernetes.io/hostname
+        operator: In
+        values:
+{{- range $group.Nodes }}
+          - {{ . }}
+{{- end }}
+---
+{{- end }}
 apiVersion: machineconfiguration.openshift.io/v1
 kind: KubeletConfig
 metadata:
-  name: virt-cpu-manager
+  name: virt-cpu-manager{{ with $group.Pool }}-{{ trimPrefix "virt-" . }}{{ end }}
 spec:
   kubeletConfig:
     # CPU Manager for pinned workloads
@@ -9,16 +44,18 @@ spec:
     cpuManagerPolicyOptions:
       full-pcpus-only: "true"
     cpuManagerReconcilePeriod: 5s
-    reservedSystemCPUs: "0-1"
+    # Housekeeping cores (with sibling threads) sized from the node topology
+    reservedSystemCPUs: {{ $group.ReservedSystemCPUs | quote }}
     # Topology Manager for NUMA awareness (required for VM pinning)
     topologyManagerPolicy: best-effort
     # Memory Manager for static memory allocation (required for VM pinning)
     memoryManagerPolicy: Static
-    # Reserved memory for NUMA node 0 (adjust based on host size)
+    # Reserved memory for NUMA node 0 (auto-sized system-reserved + hard eviction threshold)
     reservedMemory:
       - numaNode: 0
         limits:
-          memory: "1124Mi"
+          memory: {{ $group.ReservedMemory | quote }}
   machineConfigPoolSelector:
     matchLabels:
-      pools.operator.machineconfiguration.openshift.io/worker: ""
+      pools.operator.machineconfiguration.openshift.io/{{ $group.Pool | default "worker" }}: ""
+{{- end }}
@@ -1,4 +1,6 @@
 {{- if or .Hardware.PCIDevicesPresent .Hardware.GPUPresent }}
+{{- $vendors := .CPUVendors "worker" }}
+{{- $mixed := gt (len $vendors) 1 }}
 apiVersion: machineconfiguration.openshift.io/v1
 kind: MachineConfig
 metadata:
@@ -7,6 +9,51 @@ metadata:
     machineconfiguration.openshift.io/role: worker
 spec:
   kernelArguments:
+{{- /* The AMD IOMMU is enabled by default, only Intel needs intel_iommu=on */}}
+{{- if not (has "amd" $vendors) }}
     - intel_iommu=on
+{{- end }}
     - iommu=pt
+{{- /* Topology pools of kubelet-cpu-manager are split by vendor and select the virt-<vendor> role */}}
+{{- $topologyPools := (topologyGroups .).Pools }}
+{{- if $mixed }}
+{{- /* Mixed CPU vendors: intel_iommu=on goes to a custom pool of the Intel workers */}}
+{{- range $vendor := without $vendors "amd" }}
+{{- if not $topologyPools }}
+---
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfigPool
+metadata:
+  name: virt-{{ $vendor }}
+spec:
+  machineConfigSelector:
+    matchExpressions:
+      - key: machineconfiguration.openshift.io/role
+        operator: In
+        values:
+          - worker
+          - virt-{{ $vendor }}
+  nodeSelector:
+    matchLabels:
+      node-role.kubernetes.io/worker: ""
+    matchExpressions:
+      - key: feature.node.kubernetes.io/cpu-model.vendor_id
+        operator: In
+        values:
+{{- range $.CPUVendorIDs $vendor }}
+          - {{ . }}
+{{- end }}
+{{- end }}
+---
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 50-virt-pci-passthrough-{{ $vendor }}
+  labels:
+    machineconfiguration.openshift.io/role: virt-{{ $vendor }}
+spec:
+  kernelArguments:
+    - {{ $vendor }}_iommu=on
+{{- end }}
+{{- end }}
 {{- end }}
@@ -1,10 +1,15 @@
 {{- if .Hardware.NUMANodesPresent }}
+{{- /* Workload pinning must match reservedSystemCPUs of the kubelet-cpu-manager asset, which renders the pools of topology groups */}}
+{{- range $i, $group := topologyGroups . }}
+{{- if $i }}
+---
+{{- end }}
 apiVersion: machineconfiguration.openshift.io/v1
 kind: MachineConfig
 metadata:
-  name: 50-virt-numa
+  name: 50-virt-numa{{ with $group.Pool }}-{{ trimPrefix "virt-" . }}{{ end }}
   labels:
-    machineconfiguration.openshift.io/role: worker
+    machineconfiguration.openshift.io/role: {{ $group.Pool | default "worker" }}
 spec:
   config:
     ignition:
@@ -15,5 +20,6 @@ spec:
           mode: 0644
           overwrite: true
           contents:
-            source: data:text/plain;charset=utf-8;base64,eyAKICAibWFuYWdlbWVudCI6IHsKICAgICJjcHVzZXQiOiAiMC0xLDUyLTUzIgogIH0KfQo=
+            source: data:text/plain;charset=utf-8;base64,{{ dict "management" (dict "cpuset" $group.ReservedSystemCPUs) | toJson | b64enc }}
+{{- end }}
 {{- end }}
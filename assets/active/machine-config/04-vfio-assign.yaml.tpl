@@ -0,0 +1,30 @@
+{{- $devices := .VFIODevices }}
+{{- if $devices }}
+{{- $ids := list }}
+{{- range $devices }}
+{{- $ids = append $ids .ID }}
+{{- end }}
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 50-virt-vfio-assign
+  labels:
+    machineconfiguration.openshift.io/role: worker
+spec:
+  config:
+    ignition:
+      version: 3.2.0
+    storage:
+      files:
+        # Bind allowlisted devices to vfio-pci before their native driver claims them
+        - path: /etc/modprobe.d/vfio.conf
+          mode: 0644
+          overwrite: true
+          contents:
+            source: data:text/plain;charset=utf-8;base64,{{ printf "options vfio-pci ids=%s\n" (join "," $ids) | b64enc }}
+        - path: /etc/modules-load.d/vfio-pci.conf
+          mode: 0644
+          overwrite: true
+          contents:
+            source: data:text/plain;charset=utf-8;base64,{{ "vfio-pci\n" | b64enc }}
+{{- end }}
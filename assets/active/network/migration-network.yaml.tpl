@@ -0,0 +1,41 @@
+{{- with .MigrationNetwork }}
+{{- $namespace := dig "metadata" "namespace" "openshift-cnv" $.HCO.Object }}
+{{- /* NMState is a singleton: one the autopilot didn't create is left alone */}}
+{{- if or (not (objectExists "NMState" "" "nmstate")) (objectManaged "NMState" "" "nmstate") }}
+apiVersion: nmstate.io/v1
+kind: NMState
+metadata:
+  name: nmstate
+spec: {}
+---
+{{- end }}
+# VLAN interface on every worker carrying live migration traffic
+apiVersion: nmstate.io/v1
+kind: NodeNetworkConfigurationPolicy
+metadata:
+  name: {{ .Name }}
+spec:
+  nodeSelector:
+    node-role.kubernetes.io/worker: ""
+  desiredState:
+    interfaces:
+      - name: {{ .Interface }}
+        type: vlan
+        state: up
+        ipv4:
+          enabled: false
+        ipv6:
+          enabled: false
+        vlan:
+          base-iface: {{ .BaseInterface }}
+          id: {{ .VLAN }}
+---
+# Referenced by the HCO liveMigrationConfig.network, must live in the HCO namespace
+apiVersion: k8s.cni.cncf.io/v1
+kind: NetworkAttachmentDefinition
+metadata:
+  name: {{ .Name }}
+  namespace: {{ $namespace }}
+spec:
+  config: {{ dict "cniVersion" "0.3.1" "name" .Name "type" "macvlan" "master" .Interface "mode" "bridge" "ipam" (dict "type" "whereabouts" "range" .Range) | toJson | quote }}
+{{- end }}
@@ -0,0 +1,38 @@
+{{- $addresses := list }}
+{{- $ports := list }}
+{{- range .Nodes }}
+{{- if .BMCAddress }}{{ $addresses = append $addresses . }}{{ end }}
+{{- if .BMCPort }}{{ $ports = append $ports . }}{{ end }}
+{{- end }}
+apiVersion: fence-agents-remediation.medik8s.io/v1alpha1
+kind: FenceAgentsRemediationTemplate
+metadata:
+  name: virt-fence-agents-remediation-template
+  namespace: openshift-operators
+spec:
+  template:
+    spec:
+      agent: {{ dig "metadata" "annotations" "platform.kubevirt.io/fence-agent" "fence_ipmilan" .HCO.Object | quote }}
+      # BMC credentials shared by all nodes
+      sharedSecretName: {{ dig "metadata" "annotations" "platform.kubevirt.io/fence-agents-secret" "" .HCO.Object | quote }}
+      sharedparameters:
+        --lanplus: ""
+{{- if $addresses }}
+      # Per-node BMC endpoints from the platform.kubevirt.io/bmc-address and bmc-port node annotations
+      nodeparameters:
+        --ip:
+{{- range $addresses }}
+          {{ .Name | quote }}: {{ .BMCAddress | quote }}
+{{- end }}
+{{- if $ports }}
+        --ipport:
+{{- range $ports }}
+          {{ .Name | quote }}: {{ .BMCPort | quote }}
+{{- end }}
+{{- end }}
+{{- end }}
+      # Out-of-service taint lets stateful VM disks detach from the fenced node
+      remediationStrategy: OutOfServiceTaint
+      retrycount: 5
+      retryinterval: 5s
+      timeout: 60s
@@ -0,0 +1,44 @@
+{{- $farSecret := dig "metadata" "annotations" "platform.kubevirt.io/fence-agents-secret" "" .HCO.Object }}
+{{- $escalate := and $farSecret (crdExists "fenceagentsremediationtemplates.fence-agents-remediation.medik8s.io") }}
+apiVersion: remediation.medik8s.io/v1alpha1
+kind: NodeHealthCheck
+metadata:
+  name: virt-node-health-check
+  namespace: openshift-operators
+spec:
+  minHealthy: 51%
+{{- if $escalate }}
+  # Escalate to power fencing when software reboot does not recover the node
+  escalatingRemediations:
+    - order: 0
+      timeout: 5m
+      remediationTemplate:
+        apiVersion: self-node-remediation.medik8s.io/v1alpha1
+        kind: SelfNodeRemediationTemplate
+        name: self-node-remediation-automatic-strategy-template
+        namespace: openshift-operators
+    - order: 1
+      timeout: 5m
+      remediationTemplate:
+        apiVersion: fence-agents-remediation.medik8s.io/v1alpha1
+        kind: FenceAgentsRemediationTemplate
+        name: virt-fence-agents-remediation-template
+        namespace: openshift-operators
+{{- else }}
+  remediationTemplate:
+    apiVersion: self-node-remediation.medik8s.io/v1alpha1
+    kind: SelfNodeRemediationTemplate
+    name: self-node-remediation-automatic-strategy-template
+    namespace: openshift-operators
+{{- end }}
+  selector:
+    matchExpressions:
+      - key: node-role.kubernetes.io/worker
+        operator: Exists
+  unhealthyConditions:
+    - duration: 5m
+      status: "False"
+      type: Ready
+    - duration: 5m
+      status: Unknown
+      type: Ready
@@ -44,7 +44,8 @@ spec:
           # Expr: virt_platform_paused_resources > 0
           # Any resource currently paused indicates an active conflict
           # The operator has paused automation to protect the API server
-          # This metric is set to 1 when pause annotation is added, 0 when removed
+          # This metric is 1 while paused, 0 when resumed; the pause label is "auto-resume"
+          # or "permanent" (auto-resume disabled or repeated episodes)
           expr: |
             virt_platform_paused_resources > 0
           labels:
@@ -58,12 +59,15 @@ spec:
 
               Automation has been paused to protect the API server from thrashing.
 
-              Paused status: {{`{{ $value }}`}} (1=paused, 0=active)
+              Pause: {{`{{ $labels.pause }}`}} (auto-resume or permanent)
 
               This indicates another controller or user is modifying the resource,
-              conflicting with the autopilot's desired state.
+              conflicting with the autopilot's desired state. The competing field
+              managers are listed in the platform.kubevirt.io/reconcile-paused-by annotation.
 
-              To resume reconciliation, remove the annotation:
+              An auto-resume pause resolves by itself at the time in the
+              platform.kubevirt.io/reconcile-resume-at annotation; repeated pauses
+              become permanent. To resume reconciliation now, remove the annotation:
               platform.kubevirt.io/reconcile-paused="true"
             runbook_url: "https://github.com/kubevirt/virt-platform-autopilot/blob/main/docs/runbooks/VirtPlatformThrashingDetected.md"
 
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/kubevirt/virt-platform-autopilot/cmd/render"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
@@ -46,7 +48,9 @@ import (
 	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/debug"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/webhook"
 )
 
 var (
@@ -100,12 +104,23 @@ func newRunCommand() *cobra.Command {
 	var crdValidationTimeout time.Duration
 	var enableDebugServer bool
 	var development bool
+	var mode string
+	var autoResumeWindow time.Duration
+	var autoResumeMaxEpisodes int
+	var maxConcurrentAssets int
+	var enableWebhook bool
+	var webhookPort int
+	var webhookCertDir string
 
 	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "Run the platform autopilot controller",
 		Long:  `Start the controller manager that watches HyperConverged resources and manages platform configuration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			reconcileMode, err := engine.ParseMode(mode)
+			if err != nil {
+				return err
+			}
 			return runController(
 				metricsAddr,
 				debugAddr,
@@ -115,6 +130,15 @@ func newRunCommand() *cobra.Command {
 				enableDebugServer,
 				development,
 				crdValidationTimeout,
+				reconcileMode,
+				throttling.AutoResumePolicy{
+					Window:      autoResumeWindow,
+					MaxEpisodes: autoResumeMaxEpisodes,
+				},
+				maxConcurrentAssets,
+				enableWebhook,
+				webhookPort,
+				webhookCertDir,
 			)
 		},
 	}
@@ -133,6 +157,20 @@ func newRunCommand() *cobra.Command {
 		"Enable debug HTTP server with /debug/render and /debug/exclusions endpoints.")
 	cmd.Flags().BoolVar(&development, "development", true,
 		"Enable development mode logging.")
+	cmd.Flags().StringVar(&mode, "mode", string(engine.ModeEnforce),
+		"Reconciliation mode: 'enforce' applies the desired state, 'audit' only reports drift and never writes.")
+	cmd.Flags().DurationVar(&autoResumeWindow, "auto-resume-window", 0,
+		"Cool-down after which a resource paused due to an edit war is resumed automatically, "+
+			"doubling on every repeated pause. 0 disables auto-resume (pauses are permanent).")
+	cmd.Flags().IntVar(&autoResumeMaxEpisodes, "auto-resume-max-episodes", throttling.DefaultAutoResumeMaxEpisodes,
+		"Number of repeated pauses resumed automatically before a pause becomes permanent.")
+	cmd.Flags().IntVar(&maxConcurrentAssets, "max-concurrent-assets", engine.DefaultAssetConcurrency,
+		"Maximum number of assets of the same dependency level reconciled in parallel.")
+	cmd.Flags().BoolVar(&enableWebhook, "enable-webhook", false,
+		"Serve the validating webhook rejecting invalid platform.kubevirt.io annotations at write time.")
+	cmd.Flags().IntVar(&webhookPort, "webhook-port", 9443, "The port the validating webhook binds to.")
+	cmd.Flags().StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory holding the webhook serving certificate (tls.crt and tls.key).")
 
 	return cmd
 }
@@ -147,6 +185,12 @@ func runController(
 	enableDebugServer bool,
 	development bool,
 	crdValidationTimeout time.Duration,
+	mode engine.Mode,
+	autoResume throttling.AutoResumePolicy,
+	maxConcurrentAssets int,
+	enableWebhook bool,
+	webhookPort int,
+	webhookCertDir string,
 ) error {
 	// Setup logging
 	opts := zap.Options{
@@ -195,8 +239,18 @@ func runController(
 				&apiextensionsv1.CustomResourceDefinition{}: {
 					Label: labels.Everything(),
 				},
+				// Watch ConfigMaps of our namespace (labeled or not) for the overrides ConfigMap
+				// referenced by the HCO, which is created by the user
+				&corev1.ConfigMap{}: {
+					Namespaces: map[string]cache.Config{namespace: {}},
+					Label:      labels.Everything(),
+				},
 			},
 		},
+		WebhookServer: crwebhook.NewServer(crwebhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -233,6 +287,17 @@ func runController(
 		return err
 	}
 
+	reconciler.SetMode(mode)
+	reconciler.SetAutoResumePolicy(autoResume)
+	reconciler.SetAssetConcurrency(maxConcurrentAssets)
+	if autoResume.Enabled() {
+		setupLog.Info("Auto-resume of paused resources enabled",
+			"window", autoResume.Window, "maxEpisodes", autoResume.MaxEpisodes)
+	}
+	if mode == engine.ModeAudit {
+		setupLog.Info("Running in audit mode: drift is reported, nothing is written to the cluster")
+	}
+
 	// Setup event recorder
 	eventRecorder := util.NewEventRecorder(
 		mgr.GetEventRecorder("virt-platform-autopilot"),
@@ -261,6 +326,7 @@ func runController(
 		}
 
 		debugServer := debug.NewServer(mgr.GetClient(), loader, registry)
+		debugServer.SetDriftHistory(reconciler.DriftHistory())
 		debugMux := http.NewServeMux()
 		debugServer.InstallHandlers(debugMux)
 
@@ -286,6 +352,19 @@ func runController(
 		}()
 	}
 
+	// Setup validating webhook if enabled
+	// The webhook server is only started once a handler is registered
+	if enableWebhook {
+		setupLog.Info("Serving annotation validating webhook", "port", webhookPort, "path", webhook.ValidatePath)
+		// Share the controller's assets, so that dry-runs patch the baseline it reconciles
+		validator := webhook.NewAnnotationValidator(mgr.GetClient(), mgr.GetAPIReader(), reconciler.Loader(), reconciler.Registry(), namespace)
+		mgr.GetWebhookServer().Register(webhook.ValidatePath, &crwebhook.Admission{Handler: validator})
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			return err
+		}
+	}
+
 	// Setup health checks
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
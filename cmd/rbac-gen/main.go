@@ -27,9 +27,10 @@ type Resource struct {
 
 // RBACRule represents a ClusterRole rule
 type RBACRule struct {
-	APIGroups []string `yaml:"apiGroups"`
-	Resources []string `yaml:"resources"`
-	Verbs     []string `yaml:"verbs"`
+	APIGroups     []string `yaml:"apiGroups"`
+	Resources     []string `yaml:"resources"`
+	ResourceNames []string `yaml:"resourceNames,omitempty"`
+	Verbs         []string `yaml:"verbs"`
 }
 
 // ClusterRole represents the RBAC ClusterRole structure
@@ -78,6 +79,19 @@ func staticRules() []RBACRule {
 			Resources: []string{"customresourcedefinitions"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
+		// Rule 6: Node maintenance (node-level rollouts wait for NMO drains)
+		{
+			APIGroups: []string{"nodemaintenance.medik8s.io"},
+			Resources: []string{"nodemaintenances"},
+			Verbs:     []string{"get", "list"},
+		},
+		// Rule 7: Patch policy (read by name from the admin-only policy namespace)
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"configmaps"},
+			ResourceNames: []string{"virt-platform-autopilot-patch-policy"},
+			Verbs:         []string{"get"},
+		},
 		// PrometheusRule permissions are now generated dynamically from assets/active/observability/prometheus-rules.yaml.tpl
 		// This gives us both read access (for template introspection) and write access (for managing alerts)
 	}
@@ -118,6 +132,10 @@ func pluralize(kind string) string {
 		if strings.HasSuffix(kind, "s") || strings.HasSuffix(kind, "x") || strings.HasSuffix(kind, "ch") {
 			return kind + "es"
 		}
+		// Consonant + y (e.g. NodeNetworkConfigurationPolicy): policy -> policies
+		if strings.HasSuffix(kind, "y") && !strings.ContainsAny(kind[len(kind)-2:len(kind)-1], "aeiou") {
+			return strings.TrimSuffix(kind, "y") + "ies"
+		}
 		return kind + "s"
 	}
 }
@@ -211,6 +229,22 @@ func extractResources(assetsPath string) ([]Resource, error) {
 		return nil, fmt.Errorf("failed to scan active directory: %w", err)
 	}
 
+	// Objects of prune assets are deleted once no longer rendered
+	prunePaths, err := pruneAssetPaths(assetsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range prunePaths {
+		content, err := os.ReadFile(filepath.Join(assetsPath, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if strings.HasSuffix(path, ".tpl") {
+			content = preprocessTemplate(content)
+		}
+		processAssetFile(content, seen, &resources, true)
+	}
+
 	// Scan tombstones directory (best-effort - ignore NotFound)
 	tombstonesDir := filepath.Join(assetsPath, "tombstones")
 	if err := scanDirectory(tombstonesDir, seen, &resources, true); err != nil {
@@ -223,6 +257,32 @@ func extractResources(assetsPath string) ([]Resource, error) {
 	return resources, nil
 }
 
+// pruneAssetPaths returns the paths of the assets with prune: true in the asset catalog
+func pruneAssetPaths(assetsPath string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(assetsPath, "active", "metadata.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset catalog: %w", err)
+	}
+
+	var catalog struct {
+		Assets []struct {
+			Path  string `json:"path"`
+			Prune bool   `json:"prune"`
+		} `json:"assets"`
+	}
+	if err := yaml.Unmarshal(content, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	}
+
+	var paths []string
+	for _, asset := range catalog.Assets {
+		if asset.Prune {
+			paths = append(paths, asset.Path)
+		}
+	}
+	return paths, nil
+}
+
 // scanDirectory walks a directory and processes asset files
 func scanDirectory(dir string, seen map[string]bool, resources *[]Resource, needsDelete bool) error {
 	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
@@ -352,18 +412,22 @@ func formatRulesWithComments(rules []RBACRule) string {
 	writeRule(&builder, &rules[3])
 	builder.WriteString("  # CRD Discovery (for soft dependency detection and template introspection)\n")
 	writeRule(&builder, &rules[4])
+	builder.WriteString("  # Node Maintenance (node-level rollouts wait for NMO drains)\n")
+	writeRule(&builder, &rules[5])
+	builder.WriteString("  # Patch policy (read by name from the admin-only policy namespace)\n")
+	writeRule(&builder, &rules[6])
 
 	// Dynamic rules from assets
 	builder.WriteString("  # ========================================\n")
 	builder.WriteString("  # Managed Resources (Dynamic - from assets/)\n")
 	builder.WriteString("  # ========================================\n")
 
-	for i := 5; i < len(rules); i++ {
+	for i := 7; i < len(rules); i++ {
 		// Add comment based on API group
 		rule := &rules[i]
 		comment := getCommentForAPIGroup(rule.APIGroups[0])
 
-		// Check if this rule includes delete verb (tombstone cleanup)
+		// Check if this rule includes delete verb (tombstone cleanup or pruning)
 		hasDelete := false
 		for _, verb := range rule.Verbs {
 			if verb == "delete" {
@@ -374,7 +438,7 @@ func formatRulesWithComments(rules []RBACRule) string {
 
 		if comment != "" {
 			if hasDelete {
-				builder.WriteString(fmt.Sprintf("  # %s (includes tombstone cleanup)\n", comment))
+				builder.WriteString(fmt.Sprintf("  # %s (includes deletion)\n", comment))
 			} else {
 				builder.WriteString(fmt.Sprintf("  # %s\n", comment))
 			}
@@ -398,6 +462,12 @@ func writeRule(builder *strings.Builder, rule *RBACRule) {
 	for _, resource := range rule.Resources {
 		fmt.Fprintf(builder, "      - %s\n", resource)
 	}
+	if len(rule.ResourceNames) > 0 {
+		builder.WriteString("    resourceNames:\n")
+		for _, name := range rule.ResourceNames {
+			fmt.Fprintf(builder, "      - %s\n", name)
+		}
+	}
 	builder.WriteString("    verbs:\n")
 	for _, verb := range rule.Verbs {
 		fmt.Fprintf(builder, "      - %s\n", verb)
@@ -418,6 +488,12 @@ func getCommentForAPIGroup(group string) string {
 		return "Self Node Remediation"
 	case "fence-agents-remediation.medik8s.io":
 		return "Fence Agents Remediation"
+	case "nmstate.io":
+		return "Kubernetes NMState"
+	case "k8s.cni.cncf.io":
+		return "Multus NetworkAttachmentDefinitions"
+	case "oadp.openshift.io":
+		return "OpenShift API for Data Protection (OADP)"
 	case "forklift.konveyor.io":
 		return "Migration Toolkit for Virtualization (MTV)"
 	case "metallb.io":
@@ -488,5 +564,5 @@ rules:
 	}
 
 	fmt.Printf("✓ RBAC ClusterRole written to %s\n", outputFile)
-	fmt.Printf("  Total rules: %d (5 static + %d dynamic)\n", len(allRules), len(dynamicRules))
+	fmt.Printf("  Total rules: %d (6 static + %d dynamic)\n", len(allRules), len(dynamicRules))
 }
@@ -141,66 +141,70 @@ func runRender(cmd *cobra.Command, args []string) error {
 	// Render assets
 	outputs := []RenderOutput{}
 	for _, assetMeta := range assetsToRender {
-		output := RenderOutput{
-			Asset:      assetMeta.Name,
-			Path:       assetMeta.Path,
-			Component:  assetMeta.Component,
-			Conditions: assetMeta.Conditions,
-		}
+		outputs = append(outputs, renderOutputs(renderer, &assetMeta, renderCtx, showExcluded)...)
+	}
 
-		// Check conditions
-		if !checkConditions(&assetMeta, renderCtx) {
-			output.Status = "EXCLUDED"
-			output.Reason = "Conditions not met"
-			if showExcluded {
-				outputs = append(outputs, output)
-			}
-			continue
+	// Write output
+	return writeOutput(outputs, outputFormat)
+}
+
+// renderOutputs renders an asset into one output per rendered object
+// Outputs of excluded assets and filtered objects are only returned with showExcluded.
+func renderOutputs(renderer *engine.Renderer, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext, showExcluded bool) []RenderOutput {
+	output := RenderOutput{
+		Asset:      assetMeta.Name,
+		Path:       assetMeta.Path,
+		Component:  assetMeta.Component,
+		Conditions: assetMeta.Conditions,
+	}
+
+	// Check conditions
+	if !checkConditions(assetMeta, renderCtx) {
+		output.Status = "EXCLUDED"
+		output.Reason = "Conditions not met"
+		if showExcluded {
+			return []RenderOutput{output}
 		}
+		return nil
+	}
 
-		// Render asset
-		rendered, err := renderer.RenderAsset(&assetMeta, renderCtx)
-		if err != nil {
-			output.Status = "ERROR"
-			output.Reason = err.Error()
-			outputs = append(outputs, output)
-			continue
+	// Render asset, multi-document assets render several objects
+	rendered, err := renderer.RenderMultiAsset(assetMeta, renderCtx)
+	if err != nil {
+		output.Status = "ERROR"
+		output.Reason = err.Error()
+		return []RenderOutput{output}
+	}
+
+	if len(rendered) == 0 {
+		output.Status = "EXCLUDED"
+		output.Reason = "Conditional template rendered empty"
+		if showExcluded {
+			return []RenderOutput{output}
 		}
+		return nil
+	}
 
-		if rendered == nil {
-			output.Status = "EXCLUDED"
-			output.Reason = "Conditional template rendered empty"
+	// Check root exclusion (fail-open for CLI on an invalid annotation)
+	rules, _ := engine.ParseDisabledResources(renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation])
+
+	var outputs []RenderOutput
+	for _, obj := range rendered {
+		objOutput := output
+		if engine.IsResourceExcluded(obj.GetKind(), obj.GetNamespace(), obj.GetName(), rules) {
+			objOutput.Status = "FILTERED"
+			objOutput.Reason = "Root exclusion (disabled-resources annotation)"
 			if showExcluded {
-				outputs = append(outputs, output)
+				outputs = append(outputs, objOutput)
 			}
 			continue
 		}
 
-		// Check root exclusion
-		disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-		if disabledAnnotation != "" {
-			rules, err := engine.ParseDisabledResources(disabledAnnotation)
-			if err != nil {
-				// Log error but continue (fail-open for CLI)
-				continue
-			}
-			if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-				output.Status = "FILTERED"
-				output.Reason = "Root exclusion (disabled-resources annotation)"
-				if showExcluded {
-					outputs = append(outputs, output)
-				}
-				continue
-			}
-		}
-
-		output.Status = "INCLUDED"
-		output.Object = rendered
-		outputs = append(outputs, output)
+		objOutput.Status = "INCLUDED"
+		objOutput.Object = obj
+		outputs = append(outputs, objOutput)
 	}
-
-	// Write output
-	return writeOutput(outputs, outputFormat)
+	return outputs
 }
 
 // RenderOutput represents the output for a rendered asset
@@ -284,8 +288,9 @@ func checkConditions(assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.Rend
 	for _, condition := range assetMeta.Conditions {
 		switch condition.Type {
 		case assets.ConditionTypeAnnotation:
-			annotations := renderCtx.HCO.GetAnnotations()
-			if annotations[condition.Key] != condition.Value {
+			// Without a value the annotation only has to exist
+			actual, exists := renderCtx.HCO.GetAnnotations()[condition.Key]
+			if !exists || (condition.Value != "" && actual != condition.Value) {
 				return false
 			}
 		case assets.ConditionTypeFeatureGate:
@@ -28,6 +28,7 @@ import (
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 )
 
 func TestLoadHCOFromFile(t *testing.T) {
@@ -149,6 +150,28 @@ func TestCheckConditions(t *testing.T) {
 	}
 }
 
+func TestRenderOutputsMultiDocument(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetAnnotations(map[string]string{
+		pkgcontext.MigrationNetworkAnnotation: "bond0:100",
+	})
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+	asset, err := registry.GetAsset("migration-network")
+	require.NoError(t, err)
+
+	outputs := renderOutputs(engine.NewRenderer(loader), asset, renderCtx, false)
+	require.Len(t, outputs, 3, "every object of the asset should be rendered")
+	for i, kind := range []string{"NMState", "NodeNetworkConfigurationPolicy", "NetworkAttachmentDefinition"} {
+		assert.Equal(t, "INCLUDED", outputs[i].Status)
+		require.NotNil(t, outputs[i].Object)
+		assert.Equal(t, kind, outputs[i].Object.GetKind())
+	}
+}
+
 func TestWriteYAMLOutput(t *testing.T) {
 	outputs := []RenderOutput{
 		{
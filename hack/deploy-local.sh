@@ -115,6 +115,8 @@ deploy_operator() {
     kubectl apply --context "kind-$CLUSTER_NAME" -f config/rbac/service_account.yaml
     kubectl apply --context "kind-$CLUSTER_NAME" -f config/rbac/role.yaml
     kubectl apply --context "kind-$CLUSTER_NAME" -f config/rbac/role_binding.yaml
+    kubectl apply --context "kind-$CLUSTER_NAME" -f config/rbac/overrides_role.yaml
+    kubectl apply --context "kind-$CLUSTER_NAME" -f config/rbac/overrides_role_binding.yaml
 
     # Determine the actual image name to use
     # Podman prefixes with localhost/, so we need to use that in the deployment
@@ -180,6 +182,8 @@ undeploy_operator() {
     log_info "Undeploying operator"
 
     kubectl delete --context "kind-$CLUSTER_NAME" -f config/manager/manager.yaml --ignore-not-found=true
+    kubectl delete --context "kind-$CLUSTER_NAME" -f config/rbac/overrides_role_binding.yaml --ignore-not-found=true
+    kubectl delete --context "kind-$CLUSTER_NAME" -f config/rbac/overrides_role.yaml --ignore-not-found=true
     kubectl delete --context "kind-$CLUSTER_NAME" -f config/rbac/role_binding.yaml --ignore-not-found=true
     kubectl delete --context "kind-$CLUSTER_NAME" -f config/rbac/role.yaml --ignore-not-found=true
     kubectl delete --context "kind-$CLUSTER_NAME" -f config/rbac/service_account.yaml --ignore-not-found=true
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// crdDir is the directory of the vendored CRDs in the embedded filesystem
+const crdDir = "crds"
+
+// LoadCRDSchema returns the OpenAPI schema of a vendored CRD version
+// Returns nil if no vendored CRD serves the GroupVersionKind.
+func (l *Loader) LoadCRDSchema(gvk schema.GroupVersionKind) (*apiextensionsv1.JSONSchemaProps, error) {
+	l.schemasOnce.Do(func() {
+		l.schemas, l.schemasErr = l.indexCRDSchemas()
+	})
+	if l.schemasErr != nil {
+		return nil, l.schemasErr
+	}
+	return l.schemas[gvk], nil
+}
+
+// indexCRDSchemas reads all vendored CRDs and indexes their schemas by GroupVersionKind
+func (l *Loader) indexCRDSchemas() (map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps, error) {
+	schemas := make(map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps)
+
+	err := fs.WalkDir(l.fs, crdDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(filePath) != ".yaml" {
+			return nil
+		}
+
+		data, err := l.fs.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read CRD %s: %w", filePath, err)
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return fmt.Errorf("failed to parse CRD %s: %w", filePath, err)
+		}
+		if crd.Kind != "CustomResourceDefinition" {
+			return nil
+		}
+
+		for _, version := range crd.Spec.Versions {
+			if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			gvk := schema.GroupVersionKind{
+				Group:   crd.Spec.Group,
+				Version: version.Name,
+				Kind:    crd.Spec.Names.Kind,
+			}
+			schemas[gvk] = version.Schema.OpenAPIV3Schema
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to index CRD schemas: %w", err)
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HCOAssetName is the asset holding the HyperConverged golden configuration
+// It is reconciled before every other asset, because it is the source of the RenderContext.
+const HCOAssetName = "hco-golden-config"
+
+// AssetDependency declares that an asset must wait for another asset
+type AssetDependency struct {
+	// Asset is the name of the asset this one depends on
+	Asset string `json:"asset"`
+
+	// Condition, if set, must be True on every object rendered by Asset (e.g. "Available")
+	// Without it, the dependency only requires Asset to be reconciled successfully first.
+	Condition string `json:"condition,omitempty"`
+}
+
+// resolveDependencies validates depends_on references and computes dependency levels
+// Level 0 assets have no dependencies, level N assets depend on assets of level N-1 or lower.
+// Unknown references and cycles are rejected.
+func resolveDependencies(catalog *AssetCatalog) error {
+	index := make(map[string]int, len(catalog.Assets))
+	for i := range catalog.Assets {
+		name := catalog.Assets[i].Name
+		if _, exists := index[name]; exists {
+			return fmt.Errorf("duplicate asset name %s", name)
+		}
+		index[name] = i
+	}
+
+	for i := range catalog.Assets {
+		asset := &catalog.Assets[i]
+		for _, dep := range asset.DependsOn {
+			if dep.Asset == asset.Name {
+				return fmt.Errorf("asset %s depends on itself", asset.Name)
+			}
+			if _, exists := index[dep.Asset]; !exists {
+				return fmt.Errorf("asset %s depends on unknown asset %q", asset.Name, dep.Asset)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(catalog.Assets))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		asset := &catalog.Assets[i]
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			// Report the cycle starting from the first occurrence of the asset on the path
+			start := 0
+			for j, name := range path {
+				if name == asset.Name {
+					start = j
+					break
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), asset.Name)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[i] = visiting
+		path = append(path, asset.Name)
+		level := 0
+		for _, dep := range asset.DependsOn {
+			j := index[dep.Asset]
+			if err := visit(j); err != nil {
+				return err
+			}
+			level = max(level, catalog.Assets[j].DependencyLevel+1)
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		asset.DependencyLevel = level
+		return nil
+	}
+
+	for i := range catalog.Assets {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAssetsInDependencyOrder returns assets so that every asset comes after its dependencies
+// Assets are sorted by dependency level, then by reconcile_order.
+func (r *Registry) ListAssetsInDependencyOrder() []AssetMetadata {
+	sorted := make([]AssetMetadata, len(r.catalog.Assets))
+	copy(sorted, r.catalog.Assets)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DependencyLevel != sorted[j].DependencyLevel {
+			return sorted[i].DependencyLevel < sorted[j].DependencyLevel
+		}
+		return sorted[i].ReconcileOrder < sorted[j].ReconcileOrder
+	})
+
+	return sorted
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveDependencies(t *testing.T) {
+	catalog, err := parseCatalog([]byte(`
+assets:
+  - name: descheduler
+    reconcile_order: 1
+    depends_on:
+      - asset: alerts
+  - name: alerts
+    reconcile_order: 5
+    depends_on:
+      - asset: hco
+        condition: Available
+  - name: swap
+    reconcile_order: 2
+  - name: hco
+    reconcile_order: 0
+`))
+	if err != nil {
+		t.Fatalf("parseCatalog() error = %v", err)
+	}
+
+	registry := &Registry{catalog: catalog}
+	var order []string
+	for _, asset := range registry.ListAssetsInDependencyOrder() {
+		order = append(order, asset.Name)
+	}
+	// Dependencies first, reconcile_order within a level
+	if got, want := strings.Join(order, ","), "hco,swap,alerts,descheduler"; got != want {
+		t.Errorf("ListAssetsInDependencyOrder() = %s, want %s", got, want)
+	}
+
+	descheduler, _ := registry.GetAsset("descheduler")
+	if descheduler.DependencyLevel != 2 {
+		t.Errorf("descheduler level = %d, want 2", descheduler.DependencyLevel)
+	}
+}
+
+func TestResolveDependenciesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+		wantErr string
+	}{
+		{
+			name:    "unknown asset",
+			catalog: "assets:\n  - name: a\n    depends_on:\n      - asset: missing\n",
+			wantErr: `unknown asset "missing"`,
+		},
+		{
+			name:    "self dependency",
+			catalog: "assets:\n  - name: a\n    depends_on:\n      - asset: a\n",
+			wantErr: "depends on itself",
+		},
+		{
+			name: "cycle",
+			catalog: "assets:\n" +
+				"  - name: a\n    depends_on:\n      - asset: b\n" +
+				"  - name: b\n    depends_on:\n      - asset: c\n" +
+				"  - name: c\n    depends_on:\n      - asset: a\n",
+			wantErr: "dependency cycle: a -> b -> c -> a",
+		},
+		{
+			name:    "duplicate name",
+			catalog: "assets:\n  - name: a\n  - name: a\n",
+			wantErr: "duplicate asset name a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCatalog([]byte(tt.catalog))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseCatalog() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatalogDependencyOrder(t *testing.T) {
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	position := make(map[string]int)
+	for i, asset := range registry.ListAssetsInDependencyOrder() {
+		position[asset.Name] = i
+	}
+	if position[HCOAssetName] != 0 {
+		t.Errorf("%s must come first, got position %d", HCOAssetName, position[HCOAssetName])
+	}
+	if position["descheduler-loadaware"] < position["prometheus-alerts"] {
+		t.Error("descheduler-loadaware must come after prometheus-alerts")
+	}
+}
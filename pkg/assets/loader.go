@@ -22,8 +22,11 @@ import (
 	"io/fs"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 
 	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
@@ -42,6 +45,11 @@ const (
 // Loader handles loading and parsing assets from embedded filesystem
 type Loader struct {
 	fs embed.FS
+
+	// CRD schemas are indexed on first use (see LoadCRDSchema)
+	schemasOnce sync.Once
+	schemas     map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps
+	schemasErr  error
 }
 
 // NewLoader creates a new asset loader
@@ -18,6 +18,8 @@ package assets
 
 import (
 	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestNewLoader(t *testing.T) {
@@ -363,6 +365,27 @@ func TestLoader_ListAssets(t *testing.T) {
 	}
 }
 
+func TestLoader_LoadCRDSchema(t *testing.T) {
+	loader := NewLoader()
+
+	rules, err := loader.LoadCRDSchema(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"})
+	if err != nil {
+		t.Fatalf("LoadCRDSchema() error = %v", err)
+	}
+	if rules == nil {
+		t.Fatal("expected the vendored PrometheusRule schema")
+	}
+	groups := rules.Properties["spec"].Properties["groups"]
+	if groups.XListType == nil || *groups.XListType != "map" || len(groups.XListMapKeys) != 1 || groups.XListMapKeys[0] != "name" {
+		t.Errorf("spec.groups should be a list map keyed by name, got type %v keys %v", groups.XListType, groups.XListMapKeys)
+	}
+
+	unknown, err := loader.LoadCRDSchema(schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Unknown"})
+	if err != nil || unknown != nil {
+		t.Errorf("LoadCRDSchema() for unknown kind = %v, %v, want nil", unknown, err)
+	}
+}
+
 func TestCalculateDepth(t *testing.T) {
 	tests := []struct {
 		name        string
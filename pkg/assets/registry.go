@@ -19,10 +19,17 @@ package assets
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 )
 
 // InstallMode defines when an asset should be installed
@@ -46,25 +53,49 @@ const (
 type AssetCondition struct {
 	Type     ConditionType `json:"type"`
 	Detector string        `json:"detector,omitempty"` // For hardware-detection
+	Role     string        `json:"role,omitempty"`     // For hardware-detection: only consider nodes with this role
 	Key      string        `json:"key,omitempty"`      // For annotation
 	Value    string        `json:"value,omitempty"`    // For annotation/feature-gate
 }
 
+// ThrottlingPolicy overrides the anti-thrashing limits for an asset
+// Unset (zero) fields keep the operator defaults.
+type ThrottlingPolicy struct {
+	Capacity  int             `json:"capacity,omitempty"`  // Updates allowed per window
+	Window    metav1.Duration `json:"window,omitempty"`    // Token refill window, at most throttling.DefaultTTL
+	Threshold int             `json:"threshold,omitempty"` // Consecutive throttles before pausing
+	// Pause on an edit war (default true). When false, throttled updates only wait for the window
+	// to refill, for kinds whose token bucket alone bounds the damage (e.g. node reboots).
+	Pause *bool `json:"pause,omitempty"`
+}
+
 // AssetMetadata defines the metadata for a managed asset
 type AssetMetadata struct {
-	Name            string                     `json:"name"`
-	Path            string                     `json:"path"`
-	Phase           int                        `json:"phase"`
-	Install         InstallMode                `json:"install"`
-	Component       string                     `json:"component"`
-	ReconcileOrder  int                        `json:"reconcile_order"`
-	Conditions      []AssetCondition           `json:"conditions,omitempty"`
-	RenderedContent *unstructured.Unstructured `json:"-"` // Cached rendered content
+	Name            string                      `json:"name"`
+	Path            string                      `json:"path"`
+	Phase           int                         `json:"phase"`
+	Install         InstallMode                 `json:"install"`
+	Component       string                      `json:"component"`
+	ReconcileOrder  int                         `json:"reconcile_order"`
+	Conditions      []AssetCondition            `json:"conditions,omitempty"`
+	Throttling      *ThrottlingPolicy           `json:"throttling,omitempty"`
+	Disruptive      bool                        `json:"disruptive,omitempty"` // Applied only inside the HCO maintenance window
+	DependsOn       []AssetDependency           `json:"depends_on,omitempty"` // Assets reconciled (and ready) before this one
+	Prune           bool                        `json:"prune,omitempty"`      // Delete objects of the asset it no longer renders
+	KindThrottling  map[string]ThrottlingPolicy `json:"-"`                    // Catalog throttling defaults per object kind
+	DependencyLevel int                         `json:"-"`                    // Depth in the dependency graph, computed at load
+	Kinds           []schema.GroupVersionKind   `json:"-"`                    // Top-level kinds of the template, detected at load
+	RenderedContent *unstructured.Unstructured  `json:"-"`                    // Cached rendered content
 }
 
 // AssetCatalog contains all asset metadata
 type AssetCatalog struct {
 	Assets []AssetMetadata `json:"assets"`
+
+	// ThrottlingDefaults holds per-kind throttling policies (keyed by the kind of
+	// the rendered object, e.g. MachineConfig). An asset's own throttling block
+	// takes precedence field by field.
+	ThrottlingDefaults map[string]ThrottlingPolicy `json:"throttling_defaults,omitempty"`
 }
 
 // Registry manages the asset catalog and provides querying capabilities
@@ -81,9 +112,16 @@ func NewRegistry(loader *Loader) (*Registry, error) {
 		return nil, fmt.Errorf("failed to load asset catalog: %w", err)
 	}
 
-	catalog := &AssetCatalog{}
-	if err := yaml.Unmarshal(data, catalog); err != nil {
-		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	catalog, err := parseCatalog(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range catalog.Assets {
+		asset := &catalog.Assets[i]
+		if content, err := loader.LoadAsset(asset.Path); err == nil {
+			asset.Kinds = templateKinds(content)
+		}
 	}
 
 	return &Registry{
@@ -92,6 +130,140 @@ func NewRegistry(loader *Loader) (*Registry, error) {
 	}, nil
 }
 
+// Top-level keys start a line, nested ones (e.g. a kind in a spec) are indented
+var (
+	documentSeparator = regexp.MustCompile(`(?m)^---`)
+	apiVersionPattern = regexp.MustCompile(`(?m)^apiVersion:\s*["']?([A-Za-z0-9./-]+)`)
+	kindPattern       = regexp.MustCompile(`(?m)^kind:\s*["']?([A-Za-z0-9]+)`)
+)
+
+// templateKinds returns the kinds an asset template declares, without rendering it
+// The version is left empty for a document whose apiVersion can't be read.
+func templateKinds(content []byte) []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for _, document := range documentSeparator.Split(string(content), -1) {
+		kind := kindPattern.FindStringSubmatch(document)
+		if kind == nil {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Kind: kind[1]}
+		if apiVersion := apiVersionPattern.FindStringSubmatch(document); apiVersion != nil {
+			gvk = schema.FromAPIVersionAndKind(apiVersion[1], kind[1])
+		}
+		if !slices.Contains(kinds, gvk) {
+			kinds = append(kinds, gvk)
+		}
+	}
+	return kinds
+}
+
+// parseCatalog parses metadata.yaml and resolves per-asset throttling policies and dependencies
+func parseCatalog(data []byte) (*AssetCatalog, error) {
+	catalog := &AssetCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	}
+
+	for kind, policy := range catalog.ThrottlingDefaults {
+		if err := policy.validate(); err != nil {
+			return nil, fmt.Errorf("invalid throttling defaults for kind %s: %w", kind, err)
+		}
+	}
+
+	for i := range catalog.Assets {
+		asset := &catalog.Assets[i]
+		if asset.Throttling != nil {
+			if err := asset.Throttling.validate(); err != nil {
+				return nil, fmt.Errorf("invalid throttling policy for asset %s: %w", asset.Name, err)
+			}
+		}
+		// Resolved per rendered object: an asset may render kinds with different defaults
+		asset.KindThrottling = catalog.ThrottlingDefaults
+	}
+
+	if err := resolveDependencies(catalog); err != nil {
+		return nil, fmt.Errorf("invalid asset dependencies: %w", err)
+	}
+
+	return catalog, nil
+}
+
+// validate rejects limits the token bucket cannot honor
+func (p *ThrottlingPolicy) validate() error {
+	if p.Capacity < 0 {
+		return fmt.Errorf("capacity must not be negative, got %d", p.Capacity)
+	}
+	if p.Threshold < 0 {
+		return fmt.Errorf("threshold must not be negative, got %d", p.Threshold)
+	}
+	if p.Window.Duration < 0 {
+		return fmt.Errorf("window must not be negative, got %s", p.Window.Duration)
+	}
+	// Idle buckets are dropped after DefaultTTL, so a longer window would be forgotten
+	if p.Window.Duration > throttling.DefaultTTL {
+		return fmt.Errorf("window must not exceed %s, got %s", throttling.DefaultTTL, p.Window.Duration)
+	}
+	return nil
+}
+
+// inherit fills unset fields from defaults
+func (p *ThrottlingPolicy) inherit(defaults ThrottlingPolicy) {
+	if p.Capacity == 0 {
+		p.Capacity = defaults.Capacity
+	}
+	if p.Window.Duration == 0 {
+		p.Window = defaults.Window
+	}
+	if p.Threshold == 0 {
+		p.Threshold = defaults.Threshold
+	}
+	if p.Pause == nil {
+		p.Pause = defaults.Pause
+	}
+}
+
+// ThrottlingFor returns the throttling policy of the asset's objects of a kind:
+// the asset's own throttling block, completed by the catalog defaults of the kind
+// Returns nil if neither sets a limit.
+func (a *AssetMetadata) ThrottlingFor(kind string) *ThrottlingPolicy {
+	defaults, ok := a.KindThrottling[kind]
+	if !ok {
+		return a.Throttling
+	}
+	if a.Throttling == nil {
+		return &defaults
+	}
+	policy := *a.Throttling
+	policy.inherit(defaults)
+	return &policy
+}
+
+// ThrottlingLimits returns the effective token bucket capacity, window and
+// thrashing threshold for the asset's objects of a kind, falling back to the operator defaults
+func (a *AssetMetadata) ThrottlingLimits(kind string) (capacity int, window time.Duration, threshold int) {
+	capacity, window, threshold = throttling.DefaultCapacity, throttling.DefaultWindow, throttling.ThrashingThreshold
+	policy := a.ThrottlingFor(kind)
+	if policy == nil {
+		return capacity, window, threshold
+	}
+	if policy.Capacity > 0 {
+		capacity = policy.Capacity
+	}
+	if policy.Window.Duration > 0 {
+		window = policy.Window.Duration
+	}
+	if policy.Threshold > 0 {
+		threshold = policy.Threshold
+	}
+	return capacity, window, threshold
+}
+
+// PausesOnThrashing reports whether an edit war pauses the asset's objects of a kind
+func (a *AssetMetadata) PausesOnThrashing(kind string) bool {
+	policy := a.ThrottlingFor(kind)
+	return policy == nil || policy.Pause == nil || *policy.Pause
+}
+
 // GetAsset returns asset metadata by name
 func (r *Registry) GetAsset(name string) (*AssetMetadata, error) {
 	for i := range r.catalog.Assets {
@@ -129,6 +301,18 @@ func (r *Registry) ListAssetsByReconcileOrder() []AssetMetadata {
 	return sorted
 }
 
+// ListAssetsRenderingKind returns the assets that render objects of kind, in dependency order
+// Assets whose kinds couldn't be detected at load are included, so that none is missed.
+func (r *Registry) ListAssetsRenderingKind(kind string) []AssetMetadata {
+	var filtered []AssetMetadata
+	for _, asset := range r.ListAssetsInDependencyOrder() {
+		if len(asset.Kinds) == 0 || slices.ContainsFunc(asset.Kinds, func(gvk schema.GroupVersionKind) bool { return gvk.Kind == kind }) {
+			filtered = append(filtered, asset)
+		}
+	}
+	return filtered
+}
+
 // ShouldApply determines if an asset should be applied based on its conditions
 func (r *Registry) ShouldApply(ctx context.Context, asset *AssetMetadata, evalContext ConditionEvaluator) (bool, error) {
 	// Always apply if install mode is "always" and no conditions
@@ -164,10 +348,17 @@ type ConditionEvaluator interface {
 // DefaultConditionEvaluator provides default condition evaluation logic
 type DefaultConditionEvaluator struct {
 	HardwareContext map[string]bool   // Hardware detection results
+	NodeHardware    []NodeHardware    // Per-node hardware detection results
 	FeatureGates    map[string]bool   // Feature gate states
 	Annotations     map[string]string // Annotation values
 }
 
+// NodeHardware holds the hardware detection results of a single node
+type NodeHardware struct {
+	Roles     []string
+	Detectors map[string]bool
+}
+
 // EvaluateCondition evaluates a single condition
 func (e *DefaultConditionEvaluator) EvaluateCondition(ctx context.Context, condition AssetCondition) (bool, error) {
 	switch condition.Type {
@@ -175,6 +366,9 @@ func (e *DefaultConditionEvaluator) EvaluateCondition(ctx context.Context, condi
 		if condition.Detector == "" {
 			return false, fmt.Errorf("hardware-detection condition requires detector field")
 		}
+		if condition.Role != "" {
+			return e.detectedOnRole(condition.Detector, condition.Role), nil
+		}
 		detected, ok := e.HardwareContext[condition.Detector]
 		return ok && detected, nil
 
@@ -203,3 +397,20 @@ func (e *DefaultConditionEvaluator) EvaluateCondition(ctx context.Context, condi
 		return false, fmt.Errorf("unknown condition type: %s", condition.Type)
 	}
 }
+
+// detectedOnRole checks if a detector is satisfied on at least one node with the given role
+func (e *DefaultConditionEvaluator) detectedOnRole(detector, role string) bool {
+	for _, node := range e.NodeHardware {
+		hasRole := false
+		for _, r := range node.Roles {
+			if r == role {
+				hasRole = true
+				break
+			}
+		}
+		if hasRole && node.Detectors[detector] {
+			return true
+		}
+	}
+	return false
+}
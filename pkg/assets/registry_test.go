@@ -18,7 +18,13 @@ package assets
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 )
 
 func TestNewRegistry(t *testing.T) {
@@ -53,6 +59,114 @@ func TestNewRegistry(t *testing.T) {
 	})
 }
 
+func TestParseCatalogThrottling(t *testing.T) {
+	t.Run("merges kind defaults into assets", func(t *testing.T) {
+		catalog, err := parseCatalog([]byte(`
+throttling_defaults:
+  MachineConfig:
+    capacity: 1
+    window: 1h
+    threshold: 2
+    pause: false
+assets:
+  - name: swap
+    component: MachineConfig
+  - name: numa
+    component: MachineConfig
+    throttling:
+      threshold: 5
+      pause: true
+  - name: alerts
+    component: PrometheusRule
+`))
+		if err != nil {
+			t.Fatalf("parseCatalog() error = %v", err)
+		}
+
+		tests := []struct {
+			asset     int
+			kind      string
+			capacity  int
+			window    time.Duration
+			threshold int
+			pause     bool
+		}{
+			{0, "MachineConfig", 1, time.Hour, 2, false},
+			{1, "MachineConfig", 1, time.Hour, 5, true},
+			{2, "PrometheusRule", throttling.DefaultCapacity, throttling.DefaultWindow, throttling.ThrashingThreshold, true},
+			// Defaults follow the rendered kind, not the asset component
+			{0, "MachineConfigPool", throttling.DefaultCapacity, throttling.DefaultWindow, throttling.ThrashingThreshold, true},
+			{1, "MachineConfigPool", throttling.DefaultCapacity, throttling.DefaultWindow, 5, true},
+			{2, "MachineConfig", 1, time.Hour, 2, false},
+		}
+		for _, tt := range tests {
+			asset := catalog.Assets[tt.asset]
+			capacity, window, threshold := asset.ThrottlingLimits(tt.kind)
+			if capacity != tt.capacity || window != tt.window || threshold != tt.threshold {
+				t.Errorf("%s %s: ThrottlingLimits() = (%d, %s, %d), want (%d, %s, %d)",
+					asset.Name, tt.kind, capacity, window, threshold, tt.capacity, tt.window, tt.threshold)
+			}
+			if pause := asset.PausesOnThrashing(tt.kind); pause != tt.pause {
+				t.Errorf("%s %s: PausesOnThrashing() = %v, want %v", asset.Name, tt.kind, pause, tt.pause)
+			}
+		}
+
+		// Resolving a kind leaves the asset's own policy untouched
+		if catalog.Assets[1].Throttling.Capacity != 0 {
+			t.Errorf("numa throttling = %+v, want its own block only", catalog.Assets[1].Throttling)
+		}
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"negative capacity": "assets:\n  - name: a\n    throttling:\n      capacity: -1\n",
+			"negative window":   "assets:\n  - name: a\n    throttling:\n      window: -1m\n",
+			"window over TTL":   "throttling_defaults:\n  MachineConfig:\n    window: 2h\nassets: []\n",
+		} {
+			if _, err := parseCatalog([]byte(data)); err == nil {
+				t.Errorf("%s: parseCatalog() should fail", name)
+			}
+		}
+	})
+
+	t.Run("catalog limits reboot-inducing kinds", func(t *testing.T) {
+		registry, err := NewRegistry(NewLoader())
+		if err != nil {
+			t.Fatalf("Failed to create registry: %v", err)
+		}
+		for _, asset := range registry.ListAssets(nil) {
+			for _, gvk := range asset.Kinds {
+				rollsNodes := gvk.Kind == "MachineConfig" || gvk.Kind == "KubeletConfig"
+				capacity, window, _ := asset.ThrottlingLimits(gvk.Kind)
+				if rollsNodes && (capacity != 1 || window != time.Hour) {
+					t.Errorf("%s %s: expected 1 update per hour, got %d per %s", asset.Name, gvk.Kind, capacity, window)
+				}
+				if rollsNodes && asset.PausesOnThrashing(gvk.Kind) {
+					t.Errorf("%s %s: a change throttled for the rest of the hour must not pause", asset.Name, gvk.Kind)
+				}
+				if !rollsNodes && capacity == 1 && window == time.Hour {
+					t.Errorf("%s %s: got the node rollout limits", asset.Name, gvk.Kind)
+				}
+			}
+		}
+	})
+}
+
+// TestNodeLevelAssetsAreDisruptive makes sure assets that reboot nodes respect the maintenance window
+func TestNodeLevelAssetsAreDisruptive(t *testing.T) {
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	for _, asset := range registry.ListAssets(nil) {
+		nodeLevel := asset.Component == "MachineConfig" || asset.Component == "KubeletConfig"
+		if nodeLevel != asset.Disruptive {
+			t.Errorf("%s (%s): disruptive = %v, want %v", asset.Name, asset.Component, asset.Disruptive, nodeLevel)
+		}
+	}
+}
+
 func TestGetAsset(t *testing.T) {
 	loader := NewLoader()
 	registry, err := NewRegistry(loader)
@@ -202,6 +316,48 @@ func TestListAssetsByReconcileOrder(t *testing.T) {
 	})
 }
 
+func TestListAssetsRenderingKind(t *testing.T) {
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	t.Run("detects every document of a template", func(t *testing.T) {
+		asset, err := registry.GetAsset("kubelet-cpu-manager")
+		if err != nil {
+			t.Fatalf("GetAsset() error = %v", err)
+		}
+		want := []schema.GroupVersionKind{
+			{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"},
+			{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "KubeletConfig"},
+		}
+		if !reflect.DeepEqual(asset.Kinds, want) {
+			t.Errorf("Kinds = %v, want %v", asset.Kinds, want)
+		}
+	})
+
+	t.Run("filters assets by kind", func(t *testing.T) {
+		var names []string
+		for _, asset := range registry.ListAssetsRenderingKind("HyperConverged") {
+			names = append(names, asset.Name)
+		}
+		if !reflect.DeepEqual(names, []string{"hco-golden-config"}) {
+			t.Errorf("ListAssetsRenderingKind(HyperConverged) = %v, want [hco-golden-config]", names)
+		}
+		if assets := registry.ListAssetsRenderingKind("Pod"); len(assets) != 0 {
+			t.Errorf("ListAssetsRenderingKind(Pod) returned %d assets, want 0", len(assets))
+		}
+	})
+
+	t.Run("ignores nested kinds", func(t *testing.T) {
+		content := []byte("apiVersion: v1\nkind: List\nitems:\n  - kind: Pod\n---\nkind: \"Service\"\napiVersion: {{ .Version }}\n")
+		want := []schema.GroupVersionKind{{Version: "v1", Kind: "List"}, {Kind: "Service"}}
+		if got := templateKinds(content); !reflect.DeepEqual(got, want) {
+			t.Errorf("templateKinds() = %v, want %v", got, want)
+		}
+	})
+}
+
 func TestShouldApply(t *testing.T) {
 	ctx := context.Background()
 
@@ -403,6 +559,44 @@ func testHardwareDetectionConditions(ctx context.Context, t *testing.T) {
 	}
 }
 
+func TestRoleScopedHardwareDetection(t *testing.T) {
+	ctx := context.Background()
+
+	evaluator := &DefaultConditionEvaluator{
+		HardwareContext: map[string]bool{"gpuPresent": true},
+		NodeHardware: []NodeHardware{
+			{Roles: []string{"master"}, Detectors: map[string]bool{"gpuPresent": true}},
+			{Roles: []string{"worker"}, Detectors: map[string]bool{"gpuPresent": false, "vfioCapable": true}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		detector      string
+		role          string
+		wantSatisfied bool
+	}{
+		{"detected on role", "vfioCapable", "worker", true},
+		{"detected only on other role", "gpuPresent", "worker", false},
+		{"no nodes with role", "gpuPresent", "infra", false},
+		{"no role falls back to cluster-wide", "gpuPresent", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := AssetCondition{Type: ConditionTypeHardwareDetection, Detector: tt.detector, Role: tt.role}
+
+			satisfied, err := evaluator.EvaluateCondition(ctx, condition)
+			if err != nil {
+				t.Fatalf("EvaluateCondition() error = %v", err)
+			}
+			if satisfied != tt.wantSatisfied {
+				t.Errorf("EvaluateCondition() = %v, want %v", satisfied, tt.wantSatisfied)
+			}
+		})
+	}
+}
+
 func testFeatureGateConditions(ctx context.Context, t *testing.T) {
 	t.Helper()
 
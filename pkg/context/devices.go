@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// VFIODevicesAnnotation is the HCO annotation allowlisting PCI devices for vfio-pci binding
+	// Format: comma-separated vendor:device IDs with an optional resource name
+	// Example: "10de:1eb8=nvidia.com/TU104GL_Tesla_T4,10de:2236"
+	VFIODevicesAnnotation = "platform.kubevirt.io/vfio-devices"
+
+	// USBDevicesAnnotation is the HCO annotation allowlisting USB devices for passthrough
+	// Format: comma-separated vendor:product IDs with an optional resource name
+	// Devices sharing a resource name are grouped into one usbHostDevices entry
+	// Example: "0529:0001=kubevirt.io/license-dongle,0529:0003=kubevirt.io/license-dongle"
+	USBDevicesAnnotation = "platform.kubevirt.io/usb-devices"
+
+	// defaultPCIResourcePrefix is used for allowlisted PCI devices without an explicit resource name
+	defaultPCIResourcePrefix = "devices.kubevirt.io/"
+
+	// defaultUSBResourcePrefix is used for allowlisted USB devices without an explicit resource name
+	defaultUSBResourcePrefix = "devices.kubevirt.io/usb-"
+)
+
+// deviceIDPattern matches a 4-digit hexadecimal PCI/USB vendor, device or product ID
+var deviceIDPattern = regexp.MustCompile(`^[0-9a-f]{4}$`)
+
+// deviceEntry is a single parsed allowlist entry
+type deviceEntry struct {
+	vendor       string
+	device       string
+	resourceName string
+}
+
+// parseDeviceAllowlist parses comma-separated vendor:device[=resourceName] entries
+// IDs are lowercased, duplicates keep the first entry
+func parseDeviceAllowlist(value, kind, defaultResourcePrefix string) ([]deviceEntry, error) {
+	var entries []deviceEntry
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, resourceName, _ := strings.Cut(entry, "=")
+		vendor, device, ok := strings.Cut(strings.ToLower(strings.TrimSpace(id)), ":")
+		if !ok || !deviceIDPattern.MatchString(vendor) || !deviceIDPattern.MatchString(device) {
+			return nil, fmt.Errorf("invalid %s device %q: expected vendor:device with 4-digit hex IDs", kind, entry)
+		}
+
+		resourceName = strings.TrimSpace(resourceName)
+		if resourceName == "" {
+			resourceName = defaultResourcePrefix + vendor + "_" + device
+		}
+
+		if seen[vendor+":"+device] {
+			continue
+		}
+		seen[vendor+":"+device] = true
+		entries = append(entries, deviceEntry{vendor: vendor, device: device, resourceName: resourceName})
+	}
+
+	return entries, nil
+}
+
+// detectedOnWorkers collects the device IDs reported on worker nodes accepted by the filter
+func (c *RenderContext) detectedOnWorkers(devices func(*NodeInfo) []string) map[string]bool {
+	detected := make(map[string]bool)
+	for i := range c.Nodes {
+		if !c.Nodes[i].HasRole("worker") {
+			continue
+		}
+		for _, id := range devices(&c.Nodes[i]) {
+			detected[id] = true
+		}
+	}
+	return detected
+}
+
+// PCIDevice identifies a PCI device model allowed for passthrough
+type PCIDevice struct {
+	VendorID     string // Lowercase hex vendor ID (e.g. "10de")
+	DeviceID     string // Lowercase hex device ID (e.g. "1eb8")
+	ResourceName string // Resource name advertised by KubeVirt's device plugin
+}
+
+// ID returns the vendor:device ID in the format expected by vfio-pci (e.g. "10de:1eb8")
+func (d PCIDevice) ID() string {
+	return d.VendorID + ":" + d.DeviceID
+}
+
+// Selector returns the pciDeviceSelector used in HCO permittedHostDevices (e.g. "10DE:1EB8")
+func (d PCIDevice) Selector() string {
+	return strings.ToUpper(d.ID())
+}
+
+// ParsePCIDeviceAllowlist parses the vfio-devices annotation value
+func ParsePCIDeviceAllowlist(value string) ([]PCIDevice, error) {
+	entries, err := parseDeviceAllowlist(value, "PCI", defaultPCIResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []PCIDevice
+	for _, e := range entries {
+		devices = append(devices, PCIDevice{VendorID: e.vendor, DeviceID: e.device, ResourceName: e.resourceName})
+	}
+	return devices, nil
+}
+
+// VFIODevices returns the allowlisted PCI devices present on IOMMU-enabled worker nodes
+// Devices that are allowlisted but not detected are skipped, so a stale allowlist
+// never binds unknown hardware. An invalid annotation yields no devices.
+// Usage: {{ range .VFIODevices }}{{ .Selector }}{{ end }}
+func (c *RenderContext) VFIODevices() []PCIDevice {
+	if c.HCO == nil {
+		return nil
+	}
+	allowlist, err := ParsePCIDeviceAllowlist(c.HCO.GetAnnotations()[VFIODevicesAnnotation])
+	if err != nil || len(allowlist) == 0 {
+		return nil
+	}
+
+	detected := c.detectedOnWorkers(func(n *NodeInfo) []string {
+		if !n.IOMMUEnabled {
+			return nil
+		}
+		return n.PCIDevices
+	})
+
+	var devices []PCIDevice
+	for _, device := range allowlist {
+		if detected[device.ID()] {
+			devices = append(devices, device)
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].ID() < devices[j].ID() })
+	return devices
+}
+
+// USBDevice identifies a USB device model allowed for passthrough
+type USBDevice struct {
+	VendorID     string // Lowercase hex vendor ID (e.g. "0529")
+	ProductID    string // Lowercase hex product ID (e.g. "0001")
+	ResourceName string // Resource name advertised by KubeVirt's device plugin
+}
+
+// ID returns the vendor:product ID (e.g. "0529:0001")
+func (d USBDevice) ID() string {
+	return d.VendorID + ":" + d.ProductID
+}
+
+// USBHostDevice groups the USB devices exposed under one resource name,
+// mirroring an HCO permittedHostDevices.usbHostDevices entry
+type USBHostDevice struct {
+	ResourceName string
+	Selectors    []USBDevice
+}
+
+// ParseUSBDeviceAllowlist parses the usb-devices annotation value
+func ParseUSBDeviceAllowlist(value string) ([]USBDevice, error) {
+	entries, err := parseDeviceAllowlist(value, "USB", defaultUSBResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []USBDevice
+	for _, e := range entries {
+		devices = append(devices, USBDevice{VendorID: e.vendor, ProductID: e.device, ResourceName: e.resourceName})
+	}
+	return devices, nil
+}
+
+// USBHostDevices returns the allowlisted USB devices present on worker nodes, grouped by resource name
+// Devices that are allowlisted but not detected are skipped. An invalid annotation yields no devices.
+// Usage: {{ range .USBHostDevices }}{{ .ResourceName }}{{ end }}
+func (c *RenderContext) USBHostDevices() []USBHostDevice {
+	if c.HCO == nil {
+		return nil
+	}
+	allowlist, err := ParseUSBDeviceAllowlist(c.HCO.GetAnnotations()[USBDevicesAnnotation])
+	if err != nil || len(allowlist) == 0 {
+		return nil
+	}
+
+	detected := c.detectedOnWorkers(func(n *NodeInfo) []string { return n.USBDevices })
+
+	groups := make(map[string]*USBHostDevice)
+	for _, device := range allowlist {
+		if !detected[device.ID()] {
+			continue
+		}
+		group, exists := groups[device.ResourceName]
+		if !exists {
+			group = &USBHostDevice{ResourceName: device.ResourceName}
+			groups[device.ResourceName] = group
+		}
+		group.Selectors = append(group.Selectors, device)
+	}
+
+	hostDevices := make([]USBHostDevice, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.Selectors, func(i, j int) bool { return group.Selectors[i].ID() < group.Selectors[j].ID() })
+		hostDevices = append(hostDevices, *group)
+	}
+	sort.Slice(hostDevices, func(i, j int) bool { return hostDevices[i].ResourceName < hostDevices[j].ResourceName })
+	return hostDevices
+}
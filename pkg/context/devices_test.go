@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+)
+
+func TestParsePCIDeviceAllowlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []PCIDevice
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "default resource name",
+			value: "10DE:1EB8",
+			want:  []PCIDevice{{VendorID: "10de", DeviceID: "1eb8", ResourceName: "devices.kubevirt.io/10de_1eb8"}},
+		},
+		{
+			name:  "explicit resource name and whitespace",
+			value: " 10de:1eb8 = nvidia.com/TU104GL_Tesla_T4 , 8086:56c0",
+			want: []PCIDevice{
+				{VendorID: "10de", DeviceID: "1eb8", ResourceName: "nvidia.com/TU104GL_Tesla_T4"},
+				{VendorID: "8086", DeviceID: "56c0", ResourceName: "devices.kubevirt.io/8086_56c0"},
+			},
+		},
+		{
+			name:  "duplicates keep first entry",
+			value: "10de:1eb8=nvidia.com/T4,10de:1eb8",
+			want:  []PCIDevice{{VendorID: "10de", DeviceID: "1eb8", ResourceName: "nvidia.com/T4"}},
+		},
+		{name: "missing device", value: "10de", wantErr: true},
+		{name: "non-hex ID", value: "10de:zzzz", wantErr: true},
+		{name: "short ID", value: "10de:1eb", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePCIDeviceAllowlist(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePCIDeviceAllowlist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParsePCIDeviceAllowlist() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParsePCIDeviceAllowlist()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPCIDevice_Selector(t *testing.T) {
+	device := PCIDevice{VendorID: "10de", DeviceID: "1eb8"}
+	if device.ID() != "10de:1eb8" {
+		t.Errorf("ID() = %s, want 10de:1eb8", device.ID())
+	}
+	if device.Selector() != "10DE:1EB8" {
+		t.Errorf("Selector() = %s, want 10DE:1EB8", device.Selector())
+	}
+}
+
+func TestRenderContext_VFIODevices(t *testing.T) {
+	hco := NewMockHCO(HCOName, DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{
+		VFIODevicesAnnotation: "10de:2236,10de:1eb8,1002:7408",
+	})
+
+	ctx := &RenderContext{
+		HCO: hco,
+		Nodes: []NodeInfo{
+			// Detected on a worker with IOMMU
+			{Name: "worker-0", Roles: []string{"worker"}, IOMMUEnabled: true, PCIDevices: []string{"10de:1eb8", "10de:2236"}},
+			// Worker without IOMMU is ignored
+			{Name: "worker-1", Roles: []string{"worker"}, PCIDevices: []string{"1002:7408"}},
+			// Control plane nodes are ignored
+			{Name: "master-0", Roles: []string{"master"}, IOMMUEnabled: true, PCIDevices: []string{"1002:7408"}},
+		},
+	}
+
+	got := ctx.VFIODevices()
+	if len(got) != 2 || got[0].ID() != "10de:1eb8" || got[1].ID() != "10de:2236" {
+		t.Errorf("VFIODevices() = %v, want [10de:1eb8 10de:2236]", got)
+	}
+
+	hco.SetAnnotations(map[string]string{VFIODevicesAnnotation: "not-a-device"})
+	if got := ctx.VFIODevices(); got != nil {
+		t.Errorf("VFIODevices() with invalid annotation = %v, want nil", got)
+	}
+
+	hco.SetAnnotations(nil)
+	if got := ctx.VFIODevices(); got != nil {
+		t.Errorf("VFIODevices() without annotation = %v, want nil", got)
+	}
+}
+
+func TestParseUSBDeviceAllowlist(t *testing.T) {
+	got, err := ParseUSBDeviceAllowlist("0529:0001=kubevirt.io/dongle, 0529:0003")
+	if err != nil {
+		t.Fatalf("ParseUSBDeviceAllowlist() error = %v", err)
+	}
+	want := []USBDevice{
+		{VendorID: "0529", ProductID: "0001", ResourceName: "kubevirt.io/dongle"},
+		{VendorID: "0529", ProductID: "0003", ResourceName: "devices.kubevirt.io/usb-0529_0003"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseUSBDeviceAllowlist() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseUSBDeviceAllowlist()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := ParseUSBDeviceAllowlist("0529"); err == nil {
+		t.Error("ParseUSBDeviceAllowlist() should reject entries without a product ID")
+	}
+}
+
+func TestRenderContext_USBHostDevices(t *testing.T) {
+	hco := NewMockHCO(HCOName, DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{
+		USBDevicesAnnotation: "0529:0003=kubevirt.io/dongle,0529:0001=kubevirt.io/dongle,046d:c52b,1a86:7523",
+	})
+
+	ctx := &RenderContext{
+		HCO: hco,
+		Nodes: []NodeInfo{
+			{Name: "worker-0", Roles: []string{"worker"}, USBDevices: []string{"0529:0001", "0529:0003"}},
+			{Name: "worker-1", Roles: []string{"worker"}, USBDevices: []string{"046d:c52b"}},
+			// Control plane devices are ignored
+			{Name: "master-0", Roles: []string{"master"}, USBDevices: []string{"1a86:7523"}},
+		},
+	}
+
+	got := ctx.USBHostDevices()
+	if len(got) != 2 {
+		t.Fatalf("USBHostDevices() = %v, want 2 groups", got)
+	}
+
+	if got[0].ResourceName != "devices.kubevirt.io/usb-046d_c52b" || len(got[0].Selectors) != 1 {
+		t.Errorf("USBHostDevices()[0] = %v, want single 046d:c52b selector", got[0])
+	}
+	if got[1].ResourceName != "kubevirt.io/dongle" || len(got[1].Selectors) != 2 ||
+		got[1].Selectors[0].ID() != "0529:0001" || got[1].Selectors[1].ID() != "0529:0003" {
+		t.Errorf("USBHostDevices()[1] = %v, want kubevirt.io/dongle with 0529:0001 and 0529:0003", got[1])
+	}
+}
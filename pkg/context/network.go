@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MigrationNetworkAnnotation is the HCO annotation selecting a dedicated live migration network
+	// Format: NIC or bond name, VLAN ID and an optional IPv4/IPv6 range for the migration endpoints
+	// Example: "bond0:100=10.200.5.0/24"
+	MigrationNetworkAnnotation = "platform.kubevirt.io/migration-network"
+
+	// MigrationNetworkName names the NodeNetworkConfigurationPolicy and NetworkAttachmentDefinition
+	MigrationNetworkName = "virt-migration-network"
+
+	// defaultMigrationNetworkRange is used when the annotation carries no range
+	defaultMigrationNetworkRange = "192.168.200.0/24"
+
+	// maxInterfaceNameLength is the Linux limit (IFNAMSIZ - 1)
+	maxInterfaceNameLength = 15
+)
+
+// interfaceNamePattern matches Linux NIC and bond names accepted by nmstate
+var interfaceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// MigrationNetwork describes the VLAN used for live migration traffic
+type MigrationNetwork struct {
+	BaseInterface string // NIC or bond carrying the VLAN (e.g. "bond0")
+	VLAN          int    // VLAN ID (1-4094)
+	Range         string // Whereabouts IPAM range for migration endpoints (e.g. "10.200.5.0/24")
+}
+
+// Interface returns the VLAN interface name configured on the nodes (e.g. "bond0.100")
+func (n MigrationNetwork) Interface() string {
+	return fmt.Sprintf("%s.%d", n.BaseInterface, n.VLAN)
+}
+
+// Name returns the NetworkAttachmentDefinition name referenced by the HCO
+func (n MigrationNetwork) Name() string {
+	return MigrationNetworkName
+}
+
+// ParseMigrationNetwork parses the migration-network annotation value
+func ParseMigrationNetwork(value string) (*MigrationNetwork, error) {
+	spec, ipRange, hasRange := strings.Cut(strings.TrimSpace(value), "=")
+	base, vlan, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid migration network %q: expected interface:vlan[=range]", value)
+	}
+
+	base = strings.TrimSpace(base)
+	if !interfaceNamePattern.MatchString(base) {
+		return nil, fmt.Errorf("invalid migration network interface %q", base)
+	}
+
+	id, err := strconv.Atoi(strings.TrimSpace(vlan))
+	if err != nil || id < 1 || id > 4094 {
+		return nil, fmt.Errorf("invalid migration network VLAN %q: expected 1-4094", vlan)
+	}
+
+	network := &MigrationNetwork{BaseInterface: base, VLAN: id, Range: defaultMigrationNetworkRange}
+	if len(network.Interface()) > maxInterfaceNameLength {
+		return nil, fmt.Errorf("migration network interface name %q exceeds %d characters", network.Interface(), maxInterfaceNameLength)
+	}
+
+	if hasRange {
+		ipRange = strings.TrimSpace(ipRange)
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return nil, fmt.Errorf("invalid migration network range %q: %w", ipRange, err)
+		}
+		network.Range = ipRange
+	}
+
+	return network, nil
+}
+
+// MigrationNetwork returns the dedicated live migration network selected on the HCO
+// Returns nil when the annotation is missing or invalid.
+// Usage: {{ with .MigrationNetwork }}{{ .Interface }}{{ end }}
+func (c *RenderContext) MigrationNetwork() *MigrationNetwork {
+	if c.HCO == nil {
+		return nil
+	}
+	value, exists := c.HCO.GetAnnotations()[MigrationNetworkAnnotation]
+	if !exists {
+		return nil
+	}
+	network, err := ParseMigrationNetwork(value)
+	if err != nil {
+		return nil
+	}
+	return network
+}
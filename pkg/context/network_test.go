@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMigrationNetwork(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *MigrationNetwork
+		wantErr bool
+	}{
+		{
+			name:  "default range",
+			value: "bond0:100",
+			want:  &MigrationNetwork{BaseInterface: "bond0", VLAN: 100, Range: "192.168.200.0/24"},
+		},
+		{
+			name:  "explicit range and whitespace",
+			value: " ens4f0 : 200 = 10.200.5.0/24 ",
+			want:  &MigrationNetwork{BaseInterface: "ens4f0", VLAN: 200, Range: "10.200.5.0/24"},
+		},
+		{
+			name:  "IPv6 range",
+			value: "bond0:100=fd00:200::/64",
+			want:  &MigrationNetwork{BaseInterface: "bond0", VLAN: 100, Range: "fd00:200::/64"},
+		},
+		{name: "missing VLAN", value: "bond0", wantErr: true},
+		{name: "VLAN out of range", value: "bond0:4095", wantErr: true},
+		{name: "non-numeric VLAN", value: "bond0:abc", wantErr: true},
+		{name: "invalid interface", value: "bond 0:100", wantErr: true},
+		{name: "interface name too long", value: "enp175s0f1np1:100", wantErr: true},
+		{name: "invalid range", value: "bond0:100=10.200.5.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMigrationNetwork(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMigrationNetwork() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMigrationNetwork() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderContextMigrationNetwork(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{"no annotation", nil, ""},
+		{"invalid annotation", map[string]string{MigrationNetworkAnnotation: "bond0"}, ""},
+		{"valid annotation", map[string]string{MigrationNetworkAnnotation: "bond0:100"}, "bond0.100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := NewMockHCO(HCOName, DefaultHCONamespace)
+			hco.SetAnnotations(tt.annotations)
+
+			network := (&RenderContext{HCO: hco}).MigrationNetwork()
+			got := ""
+			if network != nil {
+				got = network.Interface()
+			}
+			if got != tt.want {
+				t.Errorf("MigrationNetwork().Interface() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package context
 
 import (
+	"sort"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -36,6 +39,12 @@ const (
 
 	// DefaultHCONamespace is the default namespace for HCO
 	DefaultHCONamespace = "openshift-cnv"
+
+	// CPUVendorIntel is the normalized CPU vendor for Intel processors
+	CPUVendorIntel = "intel"
+
+	// CPUVendorAMD is the normalized CPU vendor for AMD processors
+	CPUVendorAMD = "amd"
 )
 
 var (
@@ -50,7 +59,84 @@ var (
 // RenderContext contains all data needed for rendering asset templates
 type RenderContext struct {
 	HCO      *unstructured.Unstructured // Full HCO object, templates access directly
-	Hardware *HardwareContext           // Cluster-discovered hardware info
+	Hardware *HardwareContext           // Cluster-discovered hardware info (OR across all nodes)
+	Nodes    []NodeInfo                 // Per-node hardware inventory
+}
+
+// NodesWithRole returns the nodes carrying the given node-role.kubernetes.io/<role> label
+// Usage: {{ range .NodesWithRole "worker" }}...{{ end }}
+func (c *RenderContext) NodesWithRole(role string) []NodeInfo {
+	var nodes []NodeInfo
+	for i := range c.Nodes {
+		if c.Nodes[i].HasRole(role) {
+			nodes = append(nodes, c.Nodes[i])
+		}
+	}
+	return nodes
+}
+
+// CPUVendors returns the sorted, distinct normalized CPU vendors of nodes with the given role
+// An empty role considers all nodes; nodes with an unknown vendor are skipped
+// Usage: {{ $vendors := .CPUVendors "worker" }}
+func (c *RenderContext) CPUVendors(role string) []string {
+	seen := make(map[string]bool)
+	var vendors []string
+	for i := range c.Nodes {
+		node := &c.Nodes[i]
+		if node.CPUVendor == "" || (role != "" && !node.HasRole(role)) || seen[node.CPUVendor] {
+			continue
+		}
+		seen[node.CPUVendor] = true
+		vendors = append(vendors, node.CPUVendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+// CPUVendorIDs returns the sorted, distinct raw NFD vendor IDs reported by nodes of a normalized vendor
+// Needed to build node selectors, since NFD versions differ in how they report the vendor
+// Usage: {{ range $.CPUVendorIDs "amd" }}...{{ end }}
+func (c *RenderContext) CPUVendorIDs(vendor string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for i := range c.Nodes {
+		node := &c.Nodes[i]
+		if node.CPUVendor != vendor || node.CPUVendorID == "" || seen[node.CPUVendorID] {
+			continue
+		}
+		seen[node.CPUVendorID] = true
+		ids = append(ids, node.CPUVendorID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// FeatureGateEnabled reports whether a gate is listed in the HCO spec.featureGates
+// Same lookup as the feature-gate asset condition, so a template can tell whether an asset
+// gated on it is enabled.
+// Usage: {{ if .FeatureGateEnabled "CPUManager" }}...{{ end }}
+func (c *RenderContext) FeatureGateEnabled(gate string) bool {
+	gates, _, _ := unstructured.NestedStringSlice(c.HCO.Object, "spec", "featureGates")
+	for _, enabled := range gates {
+		if enabled == gate {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCPUVendor maps an NFD cpu-model.vendor_id value to a normalized vendor
+// NFD reports "Intel"/"AMD", while raw CPUID vendor strings are "GenuineIntel"/"AuthenticAMD"
+// Returns an empty string for unknown vendors
+func NormalizeCPUVendor(vendorID string) string {
+	switch strings.ToLower(vendorID) {
+	case "intel", "genuineintel":
+		return CPUVendorIntel
+	case "amd", "authenticamd":
+		return CPUVendorAMD
+	default:
+		return ""
+	}
 }
 
 // HardwareContext contains cluster hardware detection results
@@ -73,6 +159,64 @@ func (h *HardwareContext) AsMap() map[string]bool {
 	}
 }
 
+// NodeInfo contains the hardware inventory of a single node
+type NodeInfo struct {
+	Name  string   // Node name
+	Roles []string // Roles from node-role.kubernetes.io/<role> labels (e.g. "worker", "master")
+
+	// CPU and memory topology
+	CPUVendor   string // Normalized CPU vendor ("intel", "amd", or empty if unknown)
+	CPUVendorID string // Raw NFD cpu-model.vendor_id value (e.g. "Intel", "AMD")
+	NUMANodes   int    // NUMA node count (1 = UMA, 0 = unknown)
+
+	// Capacity
+	CPUs           int64 // Logical CPU count
+	ThreadsPerCore int   // Hardware threads per physical core (2 with SMT/hyperthreading)
+	Memory         int64 // Memory capacity in bytes
+
+	// Device assignment
+	IOMMUEnabled  bool             // IOMMU enabled in firmware and kernel
+	GPUVendors    []string         // GPU vendors present on the node ("nvidia", "amd", "intel")
+	PCIDevices    []string         // PCI vendor:device IDs from NFD (e.g. "10de:1eb8"), needs deviceLabelFields [class, vendor, device]
+	USBDevices    []string         // USB vendor:product IDs from NFD (e.g. "0529:0001")
+	DevicePlugins map[string]int64 // Extended resources advertised by device plugins (e.g. "nvidia.com/gpu": 2)
+	HugePages     map[string]int64 // Hugepage capacity in bytes, keyed by page size (e.g. "1Gi", "2Mi")
+
+	// Power fencing, from the platform.kubevirt.io/bmc-address and bmc-port node annotations
+	BMCAddress string // BMC address passed to the fence agent as --ip
+	BMCPort    string // BMC port passed to the fence agent as --ipport
+
+	// NFDLabels holds every feature.node.kubernetes.io/* label published by Node Feature Discovery
+	NFDLabels map[string]string
+
+	// Detector results for this node, mirroring HardwareContext
+	PCIDevicesPresent bool
+	NUMANodesPresent  bool
+	USBDevicesPresent bool
+}
+
+// HasRole checks if the node carries the given role
+func (n *NodeInfo) HasRole(role string) bool {
+	for _, r := range n.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// AsMap converts the node's detector results to a map for condition evaluation
+// Keys match HardwareContext.AsMap so the same detectors work per node
+func (n *NodeInfo) AsMap() map[string]bool {
+	return map[string]bool{
+		"pciDevicesPresent": n.PCIDevicesPresent,
+		"numaNodesPresent":  n.NUMANodesPresent,
+		"vfioCapable":       n.IOMMUEnabled,
+		"usbDevicesPresent": n.USBDevicesPresent,
+		"gpuPresent":        len(n.GPUVendors) > 0,
+	}
+}
+
 // NewRenderContext creates a new render context from an HCO object
 func NewRenderContext(hco *unstructured.Unstructured) *RenderContext {
 	return &RenderContext{
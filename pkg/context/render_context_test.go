@@ -100,3 +100,108 @@ func TestHardwareContext_AsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeInfo_AsMap(t *testing.T) {
+	node := &NodeInfo{
+		IOMMUEnabled:      true,
+		GPUVendors:        []string{"nvidia"},
+		PCIDevicesPresent: true,
+	}
+
+	got := node.AsMap()
+	want := map[string]bool{
+		"pciDevicesPresent": true,
+		"numaNodesPresent":  false,
+		"vfioCapable":       true,
+		"usbDevicesPresent": false,
+		"gpuPresent":        true,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("AsMap() returned %d entries, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("AsMap()[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestRenderContext_NodesWithRole(t *testing.T) {
+	ctx := &RenderContext{
+		Nodes: []NodeInfo{
+			{Name: "master-0", Roles: []string{"master", "control-plane"}},
+			{Name: "worker-0", Roles: []string{"worker"}},
+			{Name: "worker-1", Roles: []string{"worker"}},
+		},
+	}
+
+	tests := []struct {
+		role string
+		want []string
+	}{
+		{"worker", []string{"worker-0", "worker-1"}},
+		{"control-plane", []string{"master-0"}},
+		{"infra", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			got := ctx.NodesWithRole(tt.role)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NodesWithRole(%q) returned %d nodes, want %d", tt.role, len(got), len(tt.want))
+			}
+			for i, name := range tt.want {
+				if got[i].Name != name {
+					t.Errorf("NodesWithRole(%q)[%d] = %q, want %q", tt.role, i, got[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeCPUVendor(t *testing.T) {
+	tests := []struct {
+		vendorID string
+		want     string
+	}{
+		{"Intel", CPUVendorIntel},
+		{"GenuineIntel", CPUVendorIntel},
+		{"AMD", CPUVendorAMD},
+		{"AuthenticAMD", CPUVendorAMD},
+		{"ARM", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vendorID, func(t *testing.T) {
+			if got := NormalizeCPUVendor(tt.vendorID); got != tt.want {
+				t.Errorf("NormalizeCPUVendor(%q) = %q, want %q", tt.vendorID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderContext_CPUVendors(t *testing.T) {
+	ctx := &RenderContext{
+		Nodes: []NodeInfo{
+			{Name: "master-0", Roles: []string{"master"}, CPUVendor: "intel", CPUVendorID: "Intel"},
+			{Name: "worker-0", Roles: []string{"worker"}, CPUVendor: "amd", CPUVendorID: "AMD"},
+			{Name: "worker-1", Roles: []string{"worker"}, CPUVendor: "amd", CPUVendorID: "AuthenticAMD"},
+			{Name: "worker-2", Roles: []string{"worker"}},
+		},
+	}
+
+	if got := ctx.CPUVendors("worker"); len(got) != 1 || got[0] != "amd" {
+		t.Errorf("CPUVendors(worker) = %v, want [amd]", got)
+	}
+	if got := ctx.CPUVendors(""); len(got) != 2 || got[0] != "amd" || got[1] != "intel" {
+		t.Errorf("CPUVendors(\"\") = %v, want [amd intel]", got)
+	}
+	if got := ctx.CPUVendorIDs("amd"); len(got) != 2 || got[0] != "AMD" || got[1] != "AuthenticAMD" {
+		t.Errorf("CPUVendorIDs(amd) = %v, want [AMD AuthenticAMD]", got)
+	}
+	if got := ctx.CPUVendorIDs("intel"); len(got) != 1 || got[0] != "Intel" {
+		t.Errorf("CPUVendorIDs(intel) = %v, want [Intel]", got)
+	}
+}
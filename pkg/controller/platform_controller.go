@@ -19,10 +19,13 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -39,6 +42,9 @@ import (
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
@@ -47,14 +53,16 @@ type PlatformReconciler struct {
 	client.Client
 	Namespace string
 
+	apiReader           client.Reader // Uncached reads, e.g. of the patch policy namespace
 	loader              *assets.Loader
 	registry            *assets.Registry
 	patcher             *engine.Patcher
 	tombstoneReconciler *engine.TombstoneReconciler
-	contextBuilder      *RenderContextBuilder
+	contextBuilder      *engine.RenderContextBuilder
 	conditionEvaluator  *assets.DefaultConditionEvaluator
 	crdChecker          *util.CRDChecker
 	eventRecorder       *util.EventRecorder
+	mode                engine.Mode
 	watchedCRDs         map[string]bool    // Track CRDs we're watching to avoid restart loops
 	watchedCRDsMu       sync.RWMutex       // Protects watchedCRDs from concurrent access
 	shutdownFunc        context.CancelFunc // Graceful shutdown instead of os.Exit
@@ -76,17 +84,26 @@ func NewPlatformReconciler(c client.Client, apiReader client.Reader, namespace s
 	return &PlatformReconciler{
 		Client:              c,
 		Namespace:           namespace,
+		apiReader:           apiReader,
 		loader:              loader,
 		registry:            registry,
-		patcher:             engine.NewPatcher(c, apiReader, loader),
+		patcher:             newPatcher(c, apiReader, loader, namespace),
 		tombstoneReconciler: engine.NewTombstoneReconciler(c, loader),
-		contextBuilder:      NewRenderContextBuilder(c),
+		contextBuilder:      engine.NewRenderContextBuilder(c),
 		conditionEvaluator:  &assets.DefaultConditionEvaluator{},
 		crdChecker:          util.NewCRDChecker(apiReader), // Use apiReader (not cache-dependent)
+		mode:                engine.ModeEnforce,
 		watchedCRDs:         make(map[string]bool),
 	}, nil
 }
 
+// newPatcher creates the patcher, persisting its anti-thrashing state in namespace
+func newPatcher(c client.Client, apiReader client.Reader, loader *assets.Loader, namespace string) *engine.Patcher {
+	patcher := engine.NewPatcher(c, apiReader, loader)
+	patcher.SetThrottleCheckpointer(engine.NewThrottleCheckpointer(c, apiReader, namespace))
+	return patcher
+}
+
 // SetEventRecorder sets the event recorder for this reconciler
 func (r *PlatformReconciler) SetEventRecorder(recorder *util.EventRecorder) {
 	r.eventRecorder = recorder
@@ -104,6 +121,44 @@ func (r *PlatformReconciler) SetEventRecorder(recorder *util.EventRecorder) {
 	}
 }
 
+// SetMode sets the reconciliation mode
+// In audit mode nothing is written to the cluster: drift is only reported
+func (r *PlatformReconciler) SetMode(mode engine.Mode) {
+	r.mode = mode
+	if r.patcher != nil {
+		r.patcher.SetMode(mode)
+	}
+}
+
+// SetAutoResumePolicy sets the policy for resuming resources paused due to an edit war
+func (r *PlatformReconciler) SetAutoResumePolicy(policy throttling.AutoResumePolicy) {
+	if r.patcher != nil {
+		r.patcher.SetAutoResumePolicy(policy)
+	}
+}
+
+// SetAssetConcurrency sets how many assets of one dependency level are reconciled in parallel
+func (r *PlatformReconciler) SetAssetConcurrency(workers int) {
+	if r.patcher != nil {
+		r.patcher.SetConcurrency(workers)
+	}
+}
+
+// Loader returns the asset loader of the reconciler
+func (r *PlatformReconciler) Loader() *assets.Loader {
+	return r.loader
+}
+
+// Registry returns the asset registry of the reconciler
+func (r *PlatformReconciler) Registry() *assets.Registry {
+	return r.registry
+}
+
+// DriftHistory returns the recent drift records of the managed objects
+func (r *PlatformReconciler) DriftHistory() *engine.DriftHistory {
+	return r.patcher.DriftHistory()
+}
+
 // SetShutdownFunc sets the shutdown function for graceful operator restart
 // This allows the reconciler to trigger graceful shutdown instead of os.Exit(0)
 func (r *PlatformReconciler) SetShutdownFunc(shutdownFunc context.CancelFunc) {
@@ -146,14 +201,42 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
-	// Step 0: Process tombstones FIRST (before HCO reconciliation)
-	logger.Info("Processing tombstones")
-	deletedCount, err := r.tombstoneReconciler.ReconcileTombstones(ctx, hco)
+	// Edit-war history survives restarts: restore it before the first apply, persist it after every pass
+	r.patcher.RestoreThrottleState(ctx)
+	defer r.patcher.SaveThrottleState(ctx)
+
+	// Asset outcomes (for depends_on) are tracked per pass, starting with the HCO
+	r.patcher.BeginPass()
+	passStart := time.Now()
+	defer func() { observability.ObserveReconcilePassDuration(time.Since(passStart)) }()
+
+	// Per-asset overrides from the ConfigMap referenced by the HCO apply to the whole pass
+	assetOverrides, err := r.loadAssetOverrides(ctx, hco)
 	if err != nil {
-		// Log error but don't fail reconciliation - tombstone cleanup is best-effort
-		logger.Error(err, "Failed to process tombstones (continuing with reconciliation)")
-	} else if deletedCount > 0 {
-		logger.Info("Tombstone processing completed", "deleted", deletedCount)
+		logger.Error(err, "Failed to load overrides ConfigMap")
+		return ctrl.Result{}, err
+	}
+	r.patcher.SetAssetOverrides(assetOverrides)
+
+	// Overrides for objects that don't exist yet, applied on their creation
+	r.patcher.SetInitialOverrides(r.loadInitialOverrides(ctx, hco))
+
+	// Paths patches may write to on sensitive kinds
+	r.patcher.SetPatchPolicy(r.loadPatchPolicy(ctx, hco))
+
+	// Step 0: Process tombstones FIRST (before HCO reconciliation)
+	// Tombstones delete objects, so audit mode skips them entirely
+	if r.mode == engine.ModeAudit {
+		logger.V(1).Info("Audit mode: skipping tombstone processing")
+	} else {
+		logger.Info("Processing tombstones")
+		deletedCount, err := r.tombstoneReconciler.ReconcileTombstones(ctx, hco)
+		if err != nil {
+			// Log error but don't fail reconciliation - tombstone cleanup is best-effort
+			logger.Error(err, "Failed to process tombstones (continuing with reconciliation)")
+		} else if deletedCount > 0 {
+			logger.Info("Tombstone processing completed", "deleted", deletedCount)
+		}
 	}
 
 	// Step 1: Apply HCO golden config FIRST (reconcile_order: 0)
@@ -179,7 +262,7 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// Update condition evaluator with current context
 	r.updateConditionEvaluator(hco, renderCtx)
 
-	// Step 3: Reconcile all other assets in reconcile_order
+	// Step 3: Reconcile all other assets in dependency order
 	logger.Info("Reconciling platform assets")
 	if err := r.reconcileAssets(ctx, renderCtx); err != nil {
 		logger.Error(err, "Failed to reconcile assets")
@@ -190,12 +273,110 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 }
 
+// loadAssetOverrides reads the overrides ConfigMap referenced by the HCO
+// Returns nil if the HCO doesn't reference one. Like a malformed annotation, a missing or
+// malformed ConfigMap is reported and ignored, the pass goes on without overrides.
+// Only errors reading the ConfigMap fail the pass.
+func (r *PlatformReconciler) loadAssetOverrides(ctx context.Context, hco *unstructured.Unstructured) (map[string]overrides.AssetOverrides, error) {
+	logger := log.FromContext(ctx)
+
+	assetOverrides, err := engine.LoadAssetOverrides(ctx, r.Client, hco, r.Namespace)
+	if engine.IsInvalidOverrides(err) {
+		name := err.(*engine.InvalidOverridesError).ConfigMap
+		logger.Error(err, "Failed to load overrides ConfigMap, reconciling without overrides", "configMap", name)
+		if r.eventRecorder != nil {
+			r.eventRecorder.InvalidOverrides(hco, name, err.Error())
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.warnUnknownOverrides(ctx, hco, hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap], assetOverrides)
+	return assetOverrides, nil
+}
+
+// loadInitialOverrides parses the initial-overrides annotation of the HCO
+// Malformed entries are reported and skipped, the valid entries still apply.
+func (r *PlatformReconciler) loadInitialOverrides(ctx context.Context, hco *unstructured.Unstructured) map[string]overrides.AssetOverrides {
+	logger := log.FromContext(ctx)
+
+	initialOverrides, err := overrides.ParseInitialOverrides(hco.GetAnnotations()[overrides.AnnotationInitialOverrides])
+	if err != nil {
+		logger.Error(err, "Skipping malformed initial overrides")
+		if r.eventRecorder != nil {
+			r.eventRecorder.InvalidInitialOverrides(hco, err.Error())
+		}
+	}
+	return initialOverrides
+}
+
+// loadPatchPolicy reads the patch policy ConfigMap of the policy namespace
+// Without a policy, patches on sensitive kinds stay blocked. A malformed policy blocks them
+// as well and is reported, the rest of the pass goes on. The policy namespace is not cached,
+// so the ConfigMap is read from the API server.
+func (r *PlatformReconciler) loadPatchPolicy(ctx context.Context, hco *unstructured.Unstructured) *overrides.PatchPolicy {
+	logger := log.FromContext(ctx)
+
+	var reader client.Reader = r.Client
+	if r.apiReader != nil {
+		reader = r.apiReader
+	}
+
+	policy, err := engine.LoadPatchPolicy(ctx, reader)
+	if err != nil {
+		logger.Error(err, "Failed to load patch policy, blocking patches on sensitive kinds")
+		if r.eventRecorder != nil {
+			r.eventRecorder.InvalidPatchPolicy(hco, overrides.PatchPolicyConfigMapName, err.Error())
+		}
+		return nil
+	}
+	return policy
+}
+
+// warnUnknownOverrides reports overrides of assets that don't exist, most likely typos
+func (r *PlatformReconciler) warnUnknownOverrides(ctx context.Context, hco *unstructured.Unstructured, configMap string, assetOverrides map[string]overrides.AssetOverrides) {
+	logger := log.FromContext(ctx)
+
+	for _, name := range slices.Sorted(maps.Keys(assetOverrides)) {
+		if _, err := r.registry.GetAsset(name); err == nil {
+			continue
+		}
+		logger.Info("Overrides ConfigMap has an entry for an unknown asset", "configMap", configMap, "asset", name)
+		if r.eventRecorder != nil {
+			r.eventRecorder.InvalidOverrides(hco, configMap, fmt.Sprintf("entry for unknown asset %s is ignored", name))
+		}
+	}
+}
+
+// configMapToHCO enqueues the HCO when the patch policy or the overrides ConfigMap it references changes
+func (r *PlatformReconciler) configMapToHCO(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetNamespace() != r.Namespace {
+		return nil
+	}
+
+	key := types.NamespacedName{Name: pkgcontext.HCOName, Namespace: r.Namespace}
+	if o.GetName() != overrides.PatchPolicyConfigMapName {
+		hco := &unstructured.Unstructured{}
+		hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := r.Get(ctx, key, hco); err != nil {
+			return nil
+		}
+		if hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap] != o.GetName() {
+			return nil
+		}
+	}
+
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
 // reconcileHCO applies the golden HCO configuration
 func (r *PlatformReconciler) reconcileHCO(ctx context.Context, currentHCO *unstructured.Unstructured) error {
 	logger := log.FromContext(ctx)
 
 	// Get HCO asset from registry
-	hcoAsset, err := r.registry.GetAsset("hco-golden-config")
+	hcoAsset, err := r.registry.GetAsset(assets.HCOAssetName)
 	if err != nil {
 		return fmt.Errorf("failed to get HCO asset: %w", err)
 	}
@@ -225,8 +406,8 @@ func (r *PlatformReconciler) reconcileHCO(ctx context.Context, currentHCO *unstr
 func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkgcontext.RenderContext) error {
 	logger := log.FromContext(ctx)
 
-	// Get all assets sorted by reconcile_order (HCO should be 0, others 1+)
-	allAssets := r.registry.ListAssetsByReconcileOrder()
+	// Get all assets sorted so that dependencies come first (depends_on, then reconcile_order)
+	allAssets := r.registry.ListAssetsInDependencyOrder()
 
 	// Filter out HCO (already reconciled) and check conditions
 	var assetsToReconcile []assets.AssetMetadata
@@ -234,7 +415,7 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 		asset := &allAssets[i]
 
 		// Skip HCO (already reconciled in step 1)
-		if asset.ReconcileOrder == 0 {
+		if asset.Name == assets.HCOAssetName {
 			continue
 		}
 
@@ -276,6 +457,10 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 			logger.V(1).Info("Asset conditions not met, skipping",
 				"asset", asset.Name,
 			)
+			// Objects of a prune asset go away with the asset
+			if err := r.patcher.PruneAsset(ctx, asset, renderCtx); err != nil {
+				logger.Error(err, "Failed to prune objects of asset", "asset", asset.Name)
+			}
 			// Optionally record event (commented out to avoid spam for opt-in assets)
 			// if r.eventRecorder != nil {
 			// 	r.eventRecorder.AssetSkipped(renderCtx.HCO, asset.Name, "conditions not met")
@@ -294,8 +479,13 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 	)
 
 	// Record reconciliation event
+	// In audit mode the patcher counts drifted assets instead of applied ones
 	if r.eventRecorder != nil && err == nil {
-		r.eventRecorder.ReconcileSucceeded(renderCtx.HCO, appliedCount, len(assetsToReconcile))
+		if r.mode == engine.ModeAudit {
+			r.eventRecorder.AuditCompleted(renderCtx.HCO, appliedCount, len(assetsToReconcile))
+		} else {
+			r.eventRecorder.ReconcileSucceeded(renderCtx.HCO, appliedCount, len(assetsToReconcile))
+		}
 	}
 
 	return err
@@ -306,6 +496,16 @@ func (r *PlatformReconciler) updateConditionEvaluator(hco *unstructured.Unstruct
 	// Update hardware context
 	r.conditionEvaluator.HardwareContext = ctx.Hardware.AsMap()
 
+	// Update per-node hardware for role-scoped conditions
+	nodeHardware := make([]assets.NodeHardware, 0, len(ctx.Nodes))
+	for i := range ctx.Nodes {
+		nodeHardware = append(nodeHardware, assets.NodeHardware{
+			Roles:     ctx.Nodes[i].Roles,
+			Detectors: ctx.Nodes[i].AsMap(),
+		})
+	}
+	r.conditionEvaluator.NodeHardware = nodeHardware
+
 	// Extract feature gates from HCO
 	r.conditionEvaluator.FeatureGates = extractFeatureGates(hco)
 
@@ -443,6 +643,10 @@ func (r *PlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&apiextensionsv1.CustomResourceDefinition{},
 			r.crdEventHandler(ctx),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.configMapToHCO),
+		).
 		Named("platform")
 
 	// Dynamically add watches for resource types we manage (if their CRDs exist)
@@ -21,11 +21,9 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
@@ -423,151 +421,98 @@ func TestIsManagedCRD(t *testing.T) {
 	}
 }
 
-func TestDetectHardware(t *testing.T) {
-	ctx := context.Background()
+func TestLoadAssetOverrides(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 
-	t.Run("GPU detection", func(t *testing.T) {
-		testGPUDetection(ctx, t)
-	})
+	newHCO := func(configMap string) *unstructured.Unstructured {
+		hco := &unstructured.Unstructured{}
+		hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+		hco.SetName(pkgcontext.HCOName)
+		hco.SetNamespace("test-namespace")
+		if configMap != "" {
+			hco.SetAnnotations(map[string]string{"platform.kubevirt.io/overrides-configmap": configMap})
+		}
+		return hco
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "autopilot-overrides", Namespace: "test-namespace"},
+		Data: map[string]string{
+			"swap-enable":   "mode: unmanaged\n",
+			"unknown-asset": "ignore-fields: /spec\n",
+		},
+	}
+	invalid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid-overrides", Namespace: "test-namespace"},
+		Data:       map[string]string{"swap-enable": "modee: unmanaged\n"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, invalid).Build()
+	reconciler, _ := NewPlatformReconciler(fakeClient, fakeClient, "test-namespace")
 
-	t.Run("other hardware detection", func(t *testing.T) {
-		testOtherHardwareDetection(ctx, t)
+	t.Run("no annotation", func(t *testing.T) {
+		got, err := reconciler.loadAssetOverrides(context.Background(), newHCO(""))
+		if got != nil || err != nil {
+			t.Errorf("loadAssetOverrides() = %v, %v, want no overrides", got, err)
+		}
 	})
 
-	t.Run("handles empty node list", func(t *testing.T) {
-		scheme := runtime.NewScheme()
-		_ = corev1.AddToScheme(scheme)
-		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-
-		builder := NewRenderContextBuilder(fakeClient)
-		hardware, err := builder.detectHardware(ctx)
-
+	t.Run("referenced ConfigMap", func(t *testing.T) {
+		got, err := reconciler.loadAssetOverrides(context.Background(), newHCO("autopilot-overrides"))
 		if err != nil {
-			t.Fatalf("detectHardware() error = %v", err)
+			t.Fatalf("loadAssetOverrides() error = %v", err)
 		}
-		if hardware.GPUPresent || hardware.PCIDevicesPresent ||
-			hardware.NUMANodesPresent || hardware.VFIOCapable ||
-			hardware.USBDevicesPresent {
-			t.Error("detectHardware() detected hardware on empty node list")
+		if got["swap-enable"].Mode != "unmanaged" {
+			t.Errorf("swap-enable overrides = %+v, want mode unmanaged", got["swap-enable"])
 		}
 	})
 
-	t.Run("returns error when client fails", func(t *testing.T) {
-		scheme := runtime.NewScheme()
-		_ = corev1.AddToScheme(scheme)
-		fakeClient := &failingClient{
-			Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
-		}
-
-		builder := NewRenderContextBuilder(fakeClient)
-		_, err := builder.detectHardware(ctx)
-
-		if err == nil {
-			t.Error("detectHardware() should return error when client fails")
+	t.Run("missing or invalid ConfigMap is ignored", func(t *testing.T) {
+		for _, name := range []string{"missing-overrides", "invalid-overrides"} {
+			got, err := reconciler.loadAssetOverrides(context.Background(), newHCO(name))
+			if got != nil || err != nil {
+				t.Errorf("loadAssetOverrides(%s) = %v, %v, want no overrides", name, got, err)
+			}
 		}
 	})
 }
 
-func testGPUDetection(ctx context.Context, t *testing.T) {
-	t.Helper()
-
-	gpuTests := []struct {
-		name         string
-		resourceName string
-	}{
-		{"nvidia", "nvidia.com/gpu"},
-		{"AMD", "amd.com/gpu"},
-		{"Intel", "gpu.intel.com/i915"},
-	}
-
-	for _, tt := range gpuTests {
-		t.Run(tt.name, func(t *testing.T) {
-			scheme := runtime.NewScheme()
-			_ = corev1.AddToScheme(scheme)
-
-			node := &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"},
-				Status: corev1.NodeStatus{
-					Capacity: corev1.ResourceList{
-						corev1.ResourceName(tt.resourceName): *newQuantity(1),
-					},
-				},
-			}
+func TestLoadPatchPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 
-			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
-			builder := NewRenderContextBuilder(fakeClient)
-			hardware, err := builder.detectHardware(ctx)
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
 
-			if err != nil {
-				t.Fatalf("detectHardware() error = %v", err)
-			}
-			if !hardware.GPUPresent {
-				t.Errorf("detectHardware() did not detect %s GPU", tt.name)
-			}
-		})
+	newReconciler := func(namespace string, data map[string]string) *PlatformReconciler {
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		if data != nil {
+			builder = builder.WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "virt-platform-autopilot-patch-policy", Namespace: namespace},
+				Data:       data,
+			})
+		}
+		fakeClient := builder.Build()
+		reconciler, _ := NewPlatformReconciler(fakeClient, fakeClient, "test-namespace")
+		return reconciler
 	}
-}
 
-func testOtherHardwareDetection(ctx context.Context, t *testing.T) {
-	t.Helper()
-
-	tests := []struct {
-		name      string
-		label     string
-		checkFunc func(*pkgcontext.HardwareContext) bool
-	}{
-		{"PCI devices", "feature.node.kubernetes.io/pci-present", func(h *pkgcontext.HardwareContext) bool { return h.PCIDevicesPresent }},
-		{"NUMA topology", "feature.node.kubernetes.io/cpu-hardware_multithreading", func(h *pkgcontext.HardwareContext) bool { return h.NUMANodesPresent }},
-		{"VFIO capability", "feature.node.kubernetes.io/iommu-enabled", func(h *pkgcontext.HardwareContext) bool { return h.VFIOCapable }},
-		{"USB devices", "feature.node.kubernetes.io/usb-present", func(h *pkgcontext.HardwareContext) bool { return h.USBDevicesPresent }},
+	if policy := newReconciler("", nil).loadPatchPolicy(context.Background(), hco); policy != nil {
+		t.Errorf("loadPatchPolicy() = %v, want no policy without ConfigMap", policy)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			scheme := runtime.NewScheme()
-			_ = corev1.AddToScheme(scheme)
-
-			node := &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:   "test-node",
-					Labels: map[string]string{tt.label: "true"},
-				},
-			}
-
-			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
-			builder := NewRenderContextBuilder(fakeClient)
-			hardware, err := builder.detectHardware(ctx)
-
-			if err != nil {
-				t.Fatalf("detectHardware() error = %v", err)
-			}
-			if !tt.checkFunc(hardware) {
-				t.Errorf("detectHardware() did not detect %s", tt.name)
-			}
-		})
+	policy := newReconciler("virt-platform-autopilot-policy", map[string]string{"KubeletConfig": "/spec/kubeletConfig/maxPods"}).loadPatchPolicy(context.Background(), hco)
+	if len(policy.AllowedPaths("KubeletConfig")) != 1 {
+		t.Errorf("loadPatchPolicy() allowed paths = %v, want the KubeletConfig path", policy.AllowedPaths("KubeletConfig"))
 	}
-}
 
-// Helper to create resource quantities
-func newQuantity(value int64) *resource.Quantity {
-	q := resource.Quantity{}
-	q.Set(value)
-	return &q
-}
-
-// failingClient wraps a client to simulate failures
-type failingClient struct {
-	client.Client
-}
-
-func (f *failingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-	return &errorForTest{msg: "simulated client failure"}
-}
-
-type errorForTest struct {
-	msg string
-}
+	// Whoever may write the overrides ConfigMap can create ConfigMaps in the autopilot
+	// namespace, a policy there is not honored
+	if policy := newReconciler("test-namespace", map[string]string{"KubeletConfig": "/spec/kubeletConfig/maxPods"}).loadPatchPolicy(context.Background(), hco); policy != nil {
+		t.Errorf("loadPatchPolicy() = %v, want no policy from the autopilot namespace", policy)
+	}
 
-func (e *errorForTest) Error() string {
-	return e.msg
+	// A malformed policy blocks all patches on sensitive kinds
+	if policy := newReconciler("virt-platform-autopilot-policy", map[string]string{"Deployment": "/spec"}).loadPatchPolicy(context.Background(), hco); policy != nil {
+		t.Errorf("loadPatchPolicy() = %v, want no policy for a malformed ConfigMap", policy)
+	}
 }
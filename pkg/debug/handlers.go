@@ -39,6 +39,7 @@ type Server struct {
 	loader   *assets.Loader
 	registry *assets.Registry
 	renderer *engine.Renderer
+	drift    *engine.DriftHistory
 }
 
 // NewServer creates a new debug server
@@ -51,12 +52,19 @@ func NewServer(c client.Client, loader *assets.Loader, registry *assets.Registry
 	}
 }
 
+// SetDriftHistory sets the drift history served by the drift endpoints
+func (s *Server) SetDriftHistory(history *engine.DriftHistory) {
+	s.drift = history
+}
+
 // InstallHandlers registers debug HTTP handlers
 func (s *Server) InstallHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/render", s.handleRender)
 	mux.HandleFunc("/debug/render/", s.handleRenderAsset) // Trailing slash for path params
 	mux.HandleFunc("/debug/exclusions", s.handleExclusions)
 	mux.HandleFunc("/debug/tombstones", s.handleTombstones)
+	mux.HandleFunc("/debug/drift", s.handleDrift)
+	mux.HandleFunc("/debug/drift/", s.handleDriftObject) // Trailing slash for path params
 	mux.HandleFunc("/debug/health", s.handleHealth)
 }
 
@@ -100,62 +108,7 @@ func (s *Server) handleRender(w http.ResponseWriter, r *http.Request) {
 	assetList := s.registry.ListAssetsByReconcileOrder()
 
 	for _, assetMeta := range assetList {
-		output := RenderOutput{
-			Asset:      assetMeta.Name,
-			Path:       assetMeta.Path,
-			Component:  assetMeta.Component,
-			Conditions: assetMeta.Conditions,
-		}
-
-		// Check conditions
-		if !s.checkConditions(&assetMeta, renderCtx) {
-			output.Status = "EXCLUDED"
-			output.Reason = "Conditions not met"
-			if showExcluded {
-				outputs = append(outputs, output)
-			}
-			continue
-		}
-
-		// Render asset
-		rendered, err := s.renderer.RenderAsset(&assetMeta, renderCtx)
-		if err != nil {
-			output.Status = "ERROR"
-			output.Reason = err.Error()
-			outputs = append(outputs, output)
-			continue
-		}
-
-		if rendered == nil {
-			output.Status = "EXCLUDED"
-			output.Reason = "Conditional template rendered empty"
-			if showExcluded {
-				outputs = append(outputs, output)
-			}
-			continue
-		}
-
-		// Check root exclusion
-		disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-		if disabledAnnotation != "" {
-			rules, err := engine.ParseDisabledResources(disabledAnnotation)
-			if err != nil {
-				// Log error but continue (fail-open for debug endpoint)
-				continue
-			}
-			if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-				output.Status = "FILTERED"
-				output.Reason = "Root exclusion (disabled-resources annotation)"
-				if showExcluded {
-					outputs = append(outputs, output)
-				}
-				continue
-			}
-		}
-
-		output.Status = "INCLUDED"
-		output.Object = rendered
-		outputs = append(outputs, output)
+		outputs = append(outputs, s.renderOutputs(&assetMeta, renderCtx, showExcluded)...)
 	}
 
 	// Write response
@@ -200,6 +153,13 @@ func (s *Server) handleRenderAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.writeResponse(w, s.renderOutputs(assetMeta, renderCtx, true), format)
+}
+
+// renderOutputs renders an asset into one output per rendered object
+// Multi-document assets (e.g. a MachineConfigPool with its MachineConfigs) render several
+// objects. Outputs of excluded assets and filtered objects are only returned with showExcluded.
+func (s *Server) renderOutputs(assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext, showExcluded bool) []RenderOutput {
 	output := RenderOutput{
 		Asset:      assetMeta.Name,
 		Path:       assetMeta.Path,
@@ -211,48 +171,49 @@ func (s *Server) handleRenderAsset(w http.ResponseWriter, r *http.Request) {
 	if !s.checkConditions(assetMeta, renderCtx) {
 		output.Status = "EXCLUDED"
 		output.Reason = "Conditions not met"
-		s.writeResponse(w, []RenderOutput{output}, format)
-		return
+		if showExcluded {
+			return []RenderOutput{output}
+		}
+		return nil
 	}
 
 	// Render asset
-	rendered, err := s.renderer.RenderAsset(assetMeta, renderCtx)
+	rendered, err := s.renderer.RenderMultiAsset(assetMeta, renderCtx)
 	if err != nil {
 		output.Status = "ERROR"
 		output.Reason = err.Error()
-		s.writeResponse(w, []RenderOutput{output}, format)
-		return
+		return []RenderOutput{output}
 	}
 
-	if rendered == nil {
+	if len(rendered) == 0 {
 		output.Status = "EXCLUDED"
 		output.Reason = "Conditional template rendered empty"
-		s.writeResponse(w, []RenderOutput{output}, format)
-		return
+		if showExcluded {
+			return []RenderOutput{output}
+		}
+		return nil
 	}
 
-	// Check root exclusion
-	disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-	if disabledAnnotation != "" {
-		rules, err := engine.ParseDisabledResources(disabledAnnotation)
-		if err != nil {
-			// Log error but continue (fail-open for debug endpoint)
-			output.Status = "INCLUDED"
-			output.Object = rendered
-			s.writeResponse(w, []RenderOutput{output}, format)
-			return
-		}
-		if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-			output.Status = "FILTERED"
-			output.Reason = "Root exclusion (disabled-resources annotation)"
-			s.writeResponse(w, []RenderOutput{output}, format)
-			return
+	// Check root exclusion (fail-open for debug endpoint on an invalid annotation)
+	rules, _ := engine.ParseDisabledResources(renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation])
+
+	var outputs []RenderOutput
+	for _, obj := range rendered {
+		objOutput := output
+		if engine.IsResourceExcluded(obj.GetKind(), obj.GetNamespace(), obj.GetName(), rules) {
+			objOutput.Status = "FILTERED"
+			objOutput.Reason = "Root exclusion (disabled-resources annotation)"
+			if showExcluded {
+				outputs = append(outputs, objOutput)
+			}
+			continue
 		}
-	}
 
-	output.Status = "INCLUDED"
-	output.Object = rendered
-	s.writeResponse(w, []RenderOutput{output}, format)
+		objOutput.Status = "INCLUDED"
+		objOutput.Object = obj
+		outputs = append(outputs, objOutput)
+	}
+	return outputs
 }
 
 // ExclusionInfo represents information about excluded assets
@@ -307,8 +268,8 @@ func (s *Server) handleExclusions(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Try rendering
-		rendered, err := s.renderer.RenderAsset(&assetMeta, renderCtx)
-		if err != nil || rendered == nil {
+		rendered, err := s.renderer.RenderMultiAsset(&assetMeta, renderCtx)
+		if err != nil || len(rendered) == 0 {
 			reason := "Template rendered empty"
 			if err != nil {
 				reason = fmt.Sprintf("Render error: %v", err)
@@ -326,27 +287,31 @@ func (s *Server) handleExclusions(w http.ResponseWriter, r *http.Request) {
 
 		// Check root exclusion
 		disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-		if disabledAnnotation != "" {
-			rules, err := engine.ParseDisabledResources(disabledAnnotation)
-			if err != nil {
-				// Log error but continue (fail-open for debug endpoint)
+		if disabledAnnotation == "" {
+			continue
+		}
+		rules, err := engine.ParseDisabledResources(disabledAnnotation)
+		if err != nil {
+			// Log error but continue (fail-open for debug endpoint)
+			continue
+		}
+		for _, obj := range rendered {
+			if !engine.IsResourceExcluded(obj.GetKind(), obj.GetNamespace(), obj.GetName(), rules) {
 				continue
 			}
-			if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-				exclusion := ExclusionInfo{
-					Asset:     assetMeta.Name,
-					Path:      assetMeta.Path,
-					Component: assetMeta.Component,
-					Reason:    "Root exclusion",
-					Details: map[string]string{
-						"annotation": engine.DisabledResourcesAnnotation,
-						"value":      disabledAnnotation,
-						"resource":   fmt.Sprintf("%s/%s/%s", rendered.GetKind(), rendered.GetNamespace(), rendered.GetName()),
-					},
-					Metadata: &assetMeta,
-				}
-				exclusions = append(exclusions, exclusion)
+			exclusion := ExclusionInfo{
+				Asset:     assetMeta.Name,
+				Path:      assetMeta.Path,
+				Component: assetMeta.Component,
+				Reason:    "Root exclusion",
+				Details: map[string]string{
+					"annotation": engine.DisabledResourcesAnnotation,
+					"value":      disabledAnnotation,
+					"resource":   fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()),
+				},
+				Metadata: &assetMeta,
 			}
+			exclusions = append(exclusions, exclusion)
 		}
 	}
 
@@ -394,6 +359,61 @@ func (s *Server) handleTombstones(w http.ResponseWriter, r *http.Request) {
 	s.writeResponse(w, infos, format)
 }
 
+// handleDrift lists the recent drift records of all managed objects, newest first
+func (s *Server) handleDrift(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	records := []engine.DriftRecord{}
+	if s.drift != nil {
+		records = append(records, s.drift.List()...)
+	}
+
+	s.writeResponse(w, records, format)
+}
+
+// handleDriftObject returns the recent drift records of a single object
+// Path: /debug/drift/{kind}/{namespace}/{name}, use "_" as namespace for cluster-scoped objects
+func (s *Server) handleDriftObject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/debug/drift/"), "/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		http.Error(w, "Expected /debug/drift/{kind}/{namespace}/{name}", http.StatusBadRequest)
+		return
+	}
+	kind, namespace, name := parts[0], parts[1], parts[2]
+	if namespace == "_" {
+		namespace = ""
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	var records []engine.DriftRecord
+	if s.drift != nil {
+		records = s.drift.Get(kind, namespace, name)
+	}
+	if len(records) == 0 {
+		http.Error(w, fmt.Sprintf("No drift recorded for %s/%s/%s", kind, parts[1], name), http.StatusNotFound)
+		return
+	}
+
+	s.writeResponse(w, records, format)
+}
+
 // handleHealth is a simple health check endpoint
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
@@ -434,8 +454,9 @@ func (s *Server) checkConditions(assetMeta *assets.AssetMetadata, renderCtx *pkg
 	for _, condition := range assetMeta.Conditions {
 		switch condition.Type {
 		case assets.ConditionTypeAnnotation:
-			annotations := renderCtx.HCO.GetAnnotations()
-			if annotations[condition.Key] != condition.Value {
+			// Without a value the annotation only has to exist
+			actual, exists := renderCtx.HCO.GetAnnotations()[condition.Key]
+			if !exists || (condition.Value != "" && actual != condition.Value) {
 				return false
 			}
 		case assets.ConditionTypeFeatureGate:
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 )
 
 func TestHandleHealth(t *testing.T) {
@@ -196,6 +198,61 @@ func TestHandleRenderAsset(t *testing.T) {
 	}
 }
 
+func TestHandleRenderMultiDocumentAsset(t *testing.T) {
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	hco.SetName("kubevirt-hyperconverged")
+	hco.SetNamespace("openshift-cnv")
+	hco.SetAnnotations(map[string]string{
+		pkgcontext.MigrationNetworkAnnotation: "bond0:100",
+		engine.DisabledResourcesAnnotation:    "- kind: NMState\n  name: nmstate\n",
+	})
+
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(hco).
+		Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	server := NewServer(fakeClient, loader, registry)
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/render/migration-network?format=json", nil)
+	w := httptest.NewRecorder()
+	server.handleRenderAsset(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var outputs []RenderOutput
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &outputs))
+	require.Len(t, outputs, 3, "every object of the asset should be rendered")
+
+	assert.Equal(t, "FILTERED", outputs[0].Status)
+	assert.Nil(t, outputs[0].Object)
+	for i, kind := range []string{"NodeNetworkConfigurationPolicy", "NetworkAttachmentDefinition"} {
+		assert.Equal(t, "INCLUDED", outputs[i+1].Status)
+		require.NotNil(t, outputs[i+1].Object)
+		assert.Equal(t, kind, outputs[i+1].Object.GetKind())
+	}
+
+	// Filtered objects are only listed with show-excluded
+	req = httptest.NewRequest(http.MethodGet, "/debug/render?format=json", nil)
+	w = httptest.NewRecorder()
+	server.handleRender(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	outputs = nil
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &outputs))
+	count := 0
+	for _, out := range outputs {
+		if out.Asset == "migration-network" {
+			assert.Equal(t, "INCLUDED", out.Status)
+			count++
+		}
+	}
+	assert.Equal(t, 2, count)
+}
+
 func TestHandleExclusions(t *testing.T) {
 	// Create fake HCO
 	hco := &unstructured.Unstructured{}
@@ -333,6 +390,7 @@ func TestMethodNotAllowed(t *testing.T) {
 		"/debug/render",
 		"/debug/exclusions",
 		"/debug/tombstones",
+		"/debug/drift",
 	}
 
 	for _, endpoint := range endpoints {
@@ -348,3 +406,58 @@ func TestMethodNotAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDrift(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	history := engine.NewDriftHistory(engine.DefaultDriftHistorySize)
+	history.Record(engine.DriftRecord{
+		Timestamp: time.Now(),
+		Asset:     "swap-enable",
+		Kind:      "MachineConfig",
+		Name:      "90-worker-swap",
+		Action:    "update",
+		Fields: []engine.FieldDrift{
+			{Path: "spec.config.ignition.version", Live: "3.2.0", Desired: "3.4.0", Manager: "kubectl-edit"},
+		},
+	})
+
+	server := NewServer(nil, loader, registry)
+	server.SetDriftHistory(history)
+	mux := http.NewServeMux()
+	server.InstallHandlers(mux)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCount  int
+	}{
+		{name: "all drift", path: "/debug/drift?format=json", wantStatus: http.StatusOK, wantCount: 1},
+		{name: "cluster-scoped object", path: "/debug/drift/machineconfig/_/90-worker-swap?format=json", wantStatus: http.StatusOK, wantCount: 1},
+		{name: "unknown object", path: "/debug/drift/MachineConfig/_/other", wantStatus: http.StatusNotFound},
+		{name: "malformed path", path: "/debug/drift/MachineConfig", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var records []engine.DriftRecord
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &records))
+			require.Len(t, records, tt.wantCount)
+			assert.Equal(t, "kubectl-edit", records[0].Fields[0].Manager)
+			assert.Equal(t, "3.4.0", records[0].Fields[0].Desired)
+		})
+	}
+}
@@ -35,6 +35,9 @@ const (
 
 	// ManagedByValue is the label value for objects managed by this autopilot
 	ManagedByValue = "virt-platform-autopilot"
+
+	// AssetLabel names the asset of objects whose asset prunes them once no longer rendered
+	AssetLabel = "platform.kubevirt.io/asset"
 )
 
 // Applier handles Server-Side Apply operations
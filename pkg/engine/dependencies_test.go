@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func newOperatorCR(available string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": available},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Operator"})
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName(strings.ToLower(available))
+	return obj
+}
+
+func TestUnmetDependency(t *testing.T) {
+	ready := newOperatorCR("True")
+	notReady := newOperatorCR("False")
+	fakeClient := fake.NewClientBuilder().WithObjects(ready, notReady).Build()
+	p := NewPatcher(fakeClient, nil, assets.NewLoader())
+	ctx := t.Context()
+
+	p.BeginPass()
+	p.recordResult("ok", &assetResult{objects: []*unstructured.Unstructured{ready}})
+	p.recordResult("starting", &assetResult{objects: []*unstructured.Unstructured{ready, notReady}})
+	p.recordResult("broken", &assetResult{err: errors.New("apply failed")})
+	p.recordResult("skipped", &assetResult{skipped: "dependency broken failed"})
+
+	tests := []struct {
+		name       string
+		dependsOn  []assets.AssetDependency
+		wantReason string
+	}{
+		{"no dependencies", nil, ""},
+		{"reconciled dependency", []assets.AssetDependency{{Asset: "ok"}}, ""},
+		{"ready dependency", []assets.AssetDependency{{Asset: "ok", Condition: "Available"}}, ""},
+		{"failed dependency", []assets.AssetDependency{{Asset: "broken"}}, "dependency broken failed"},
+		{"skipped dependency", []assets.AssetDependency{{Asset: "skipped"}}, "dependency skipped was skipped (dependency broken failed)"},
+		{"not applicable", []assets.AssetDependency{{Asset: "filtered"}}, ""},
+		{"not applicable with condition", []assets.AssetDependency{{Asset: "filtered", Condition: "Available"}}, "dependency filtered is not applicable"},
+		{"not ready", []assets.AssetDependency{{Asset: "starting", Condition: "Available"}}, "waiting for Operator false of dependency starting to become Available"},
+		{"unknown condition", []assets.AssetDependency{{Asset: "ok", Condition: "Upgradeable"}}, "waiting for Operator true of dependency ok to become Upgradeable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := &assets.AssetMetadata{Name: "dependent", DependsOn: tt.dependsOn}
+			if got := p.unmetDependency(ctx, asset); got != tt.wantReason {
+				t.Errorf("unmetDependency() = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+
+	// A new pass forgets the outcomes of the previous one
+	p.BeginPass()
+	asset := &assets.AssetMetadata{Name: "dependent", DependsOn: []assets.AssetDependency{{Asset: "ok", Condition: "Available"}}}
+	if got := p.unmetDependency(ctx, asset); got != "dependency ok is not applicable" {
+		t.Errorf("unmetDependency() after BeginPass = %q", got)
+	}
+}
+
+func TestReconcileAssetsByLevel(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	p := NewPatcher(fakeClient, nil, assets.NewLoader())
+	p.SetConcurrency(2)
+	p.BeginPass()
+
+	// Templates that don't exist fail to render, every level-0 asset fails
+	catalog := []assets.AssetMetadata{
+		{Name: "a", Path: "missing/a.yaml"},
+		{Name: "b", Path: "missing/b.yaml"},
+		{Name: "c", Path: "missing/c.yaml"},
+		{Name: "dependent", Path: "missing/d.yaml", DependsOn: []assets.AssetDependency{{Asset: "b"}}, DependencyLevel: 1},
+	}
+
+	applied, err := p.ReconcileAssets(t.Context(), catalog, &pkgcontext.RenderContext{})
+	if applied != 0 || err == nil {
+		t.Fatalf("ReconcileAssets() = %d, %v, want failures", applied, err)
+	}
+
+	// Failures are reported in asset order, whatever order the workers finished in
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "failed to reconcile 3/4 assets: [a: ") {
+		t.Errorf("ReconcileAssets() error = %q", msg)
+	}
+	if !(strings.Index(msg, "[a: ") < strings.Index(msg, "[b: ") && strings.Index(msg, "[b: ") < strings.Index(msg, "[c: ")) {
+		t.Errorf("ReconcileAssets() error not in asset order: %q", msg)
+	}
+
+	// The next level only starts once its dependency has failed
+	if got := p.results["dependent"]; got == nil || got.skipped != "dependency b failed" {
+		t.Errorf("dependent result = %+v, want skipped", got)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -41,19 +43,61 @@ func NewDriftDetector(c client.Client) *DriftDetector {
 
 // DetectDrift checks if applying desired would change live object
 // Uses SSA dry-run to accurately detect drift
-func (d *DriftDetector) DetectDrift(ctx context.Context, desired, live *unstructured.Unstructured) (bool, error) {
+// When drift is found, the returned diff holds the drifted fields in structuredDiff format
+func (d *DriftDetector) DetectDrift(ctx context.Context, desired, live *unstructured.Unstructured) (bool, map[string]interface{}, error) {
 	logger := log.FromContext(ctx)
 
 	if desired == nil {
-		return false, fmt.Errorf("desired object is nil")
+		return false, nil, fmt.Errorf("desired object is nil")
 	}
 
 	// If live object doesn't exist, there's drift (needs creation)
 	if live == nil {
-		return true, nil
+		return true, structuredDiff(nil, sanitizeObject(desired)), nil
 	}
 
 	// Perform SSA dry-run to see what would change
+	dryRunObj, err := d.dryRunApply(ctx, desired)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Sanitize both objects for comparison (remove runtime fields)
+	sanitizedDryRun := sanitizeObject(dryRunObj)
+	sanitizedLive := sanitizeObject(live)
+
+	// Compare the objects
+	hasDrift := !equality.Semantic.DeepEqual(sanitizedDryRun, sanitizedLive)
+	if !hasDrift {
+		return false, nil, nil
+	}
+
+	diff := structuredDiff(sanitizedLive, sanitizedDryRun)
+	logger.V(1).Info("Drift detected",
+		"kind", desired.GetKind(),
+		"namespace", desired.GetNamespace(),
+		"name", desired.GetName(),
+		"diff", diff,
+	)
+
+	return true, diff, nil
+}
+
+// Diff returns the fields that applying desired would change on live, in structuredDiff format
+// Uses the SSA dry-run result when possible, so server-side defaults don't show up as drift.
+// A nil live object (pending creation) reports every desired field.
+func (d *DriftDetector) Diff(ctx context.Context, desired, live *unstructured.Unstructured) map[string]interface{} {
+	target := desired
+	if live != nil {
+		if dryRunObj, err := d.dryRunApply(ctx, desired); err == nil {
+			target = dryRunObj
+		}
+	}
+	return structuredDiff(sanitizeObject(live), sanitizeObject(target))
+}
+
+// dryRunApply returns the object the API server would persist if desired were applied
+func (d *DriftDetector) dryRunApply(ctx context.Context, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	dryRunObj := desired.DeepCopy()
 
 	// Use modern Apply() API with dry-run for drift detection
@@ -65,28 +109,11 @@ func (d *DriftDetector) DetectDrift(ctx context.Context, desired, live *unstruct
 
 	// Convert unstructured to ApplyConfiguration
 	applyConfig := client.ApplyConfigurationFromUnstructured(dryRunObj)
-	err := d.client.Apply(ctx, applyConfig, applyOptions...)
-	if err != nil {
-		return false, fmt.Errorf("failed to perform dry-run apply: %w", err)
+	if err := d.client.Apply(ctx, applyConfig, applyOptions...); err != nil {
+		return nil, fmt.Errorf("failed to perform dry-run apply: %w", err)
 	}
 
-	// Sanitize both objects for comparison (remove runtime fields)
-	sanitizedDryRun := sanitizeObject(dryRunObj)
-	sanitizedLive := sanitizeObject(live)
-
-	// Compare the objects
-	hasDrift := !equality.Semantic.DeepEqual(sanitizedDryRun, sanitizedLive)
-
-	if hasDrift {
-		logger.V(1).Info("Drift detected",
-			"kind", desired.GetKind(),
-			"namespace", desired.GetNamespace(),
-			"name", desired.GetName(),
-			"diff", structuredDiff(sanitizedLive, sanitizedDryRun),
-		)
-	}
-
-	return hasDrift, nil
+	return dryRunObj, nil
 }
 
 // SimpleDriftCheck performs a simple comparison without SSA dry-run
@@ -171,6 +198,75 @@ func structuredDiff(live, desired map[string]interface{}) map[string]interface{}
 	return result
 }
 
+// DiffFieldPaths returns the sorted paths of the leaf differences in a structuredDiff result
+// Paths are formatted by formatFieldPath, e.g. metadata.labels[app.kubernetes.io/name].
+func DiffFieldPaths(diff map[string]interface{}) []string {
+	segments := diffFieldSegments(diff)
+	paths := make([]string, 0, len(segments))
+	for _, path := range segments {
+		paths = append(paths, formatFieldPath(path))
+	}
+	return paths
+}
+
+// diffFieldSegments returns the keys leading to every leaf difference of a structuredDiff result,
+// sorted by formatted path. Keys are kept apart since map keys (e.g. label names) may contain dots.
+func diffFieldSegments(diff map[string]interface{}) [][]string {
+	var paths [][]string
+	collectDiffPaths(diff, nil, &paths)
+	sort.Slice(paths, func(i, j int) bool {
+		return formatFieldPath(paths[i]) < formatFieldPath(paths[j])
+	})
+	return paths
+}
+
+// collectDiffPaths walks a structuredDiff result, appending the keys of every live/desired leaf
+func collectDiffPaths(diff map[string]interface{}, prefix []string, paths *[][]string) {
+	for key, value := range diff {
+		path := append(append(make([]string, 0, len(prefix)+1), prefix...), key)
+		sub, ok := value.(map[string]interface{})
+		if ok && !isDiffLeaf(sub) {
+			collectDiffPaths(sub, path, paths)
+			continue
+		}
+		*paths = append(*paths, path)
+	}
+}
+
+// formatFieldPath joins the keys of a field path with dots, bracketing keys that contain a dot
+func formatFieldPath(path []string) string {
+	var builder strings.Builder
+	for i, key := range path {
+		switch {
+		case strings.Contains(key, "."):
+			builder.WriteString("[" + key + "]")
+		case i > 0:
+			builder.WriteString("." + key)
+		default:
+			builder.WriteString(key)
+		}
+	}
+	return builder.String()
+}
+
+// isDiffLeaf reports whether a structuredDiff value is a {"live", "desired"} pair
+func isDiffLeaf(value map[string]interface{}) bool {
+	if len(value) != 2 {
+		return false
+	}
+	_, hasLive := value["live"]
+	_, hasDesired := value["desired"]
+	return hasLive && hasDesired
+}
+
+// summarizePaths joins up to limit paths, noting how many were left out
+func summarizePaths(paths []string, limit int) string {
+	if len(paths) <= limit {
+		return strings.Join(paths, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:limit], ", "), len(paths)-limit)
+}
+
 // CompareSpecs compares only the spec sections of two objects
 func CompareSpecs(obj1, obj2 *unstructured.Unstructured) bool {
 	if obj1 == nil || obj2 == nil {
@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultDriftHistorySize is the number of drift records kept per managed object
+const DefaultDriftHistorySize = 10
+
+// DefaultDriftHistoryObjects is the number of objects whose drift history is kept
+// Objects that are deleted or renamed are never forgotten otherwise.
+const DefaultDriftHistoryObjects = 500
+
+// FieldDrift describes a single drifted field
+type FieldDrift struct {
+	Path    string      `json:"path" yaml:"path"`
+	Live    interface{} `json:"live" yaml:"live"`
+	Desired interface{} `json:"desired" yaml:"desired"`
+	// Manager is the field manager that last wrote the live value (from managedFields)
+	Manager string `json:"manager,omitempty" yaml:"manager,omitempty"`
+}
+
+// DriftRecord is one observed drift of a managed object
+type DriftRecord struct {
+	Timestamp time.Time    `json:"timestamp" yaml:"timestamp"`
+	Asset     string       `json:"asset" yaml:"asset"`
+	Kind      string       `json:"kind" yaml:"kind"`
+	Namespace string       `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string       `json:"name" yaml:"name"`
+	Action    string       `json:"action" yaml:"action"` // "create" or "update"
+	Fields    []FieldDrift `json:"fields" yaml:"fields"`
+}
+
+// Paths returns the field paths of the record
+func (r *DriftRecord) Paths() []string {
+	paths := make([]string, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		paths = append(paths, field.Path)
+	}
+	return paths
+}
+
+// CompetingManager is another field manager that changed fields the autopilot manages
+type CompetingManager struct {
+	Manager string
+	Fields  []string
+}
+
+// CompetingManagers groups the drifted fields of the record by the manager that last wrote them
+// Managers owning the most fields come first. Fields without a known manager are left out.
+func (r *DriftRecord) CompetingManagers() []CompetingManager {
+	byManager := make(map[string][]string)
+	for _, field := range r.Fields {
+		if field.Manager != "" {
+			byManager[field.Manager] = append(byManager[field.Manager], field.Path)
+		}
+	}
+
+	competitors := make([]CompetingManager, 0, len(byManager))
+	for manager, fields := range byManager {
+		competitors = append(competitors, CompetingManager{Manager: manager, Fields: fields})
+	}
+	sort.Slice(competitors, func(i, j int) bool {
+		if len(competitors[i].Fields) != len(competitors[j].Fields) {
+			return len(competitors[i].Fields) > len(competitors[j].Fields)
+		}
+		return competitors[i].Manager < competitors[j].Manager
+	})
+	return competitors
+}
+
+// summarizeCompetitors formats competing managers as "manager (path, path); manager (path)"
+func summarizeCompetitors(competitors []CompetingManager) string {
+	parts := make([]string, 0, len(competitors))
+	for _, competitor := range competitors {
+		parts = append(parts, fmt.Sprintf("%s (%s)", competitor.Manager, summarizePaths(competitor.Fields, maxEventFieldPaths)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DriftHistory keeps the most recent drift records of each managed object in memory
+// Each object has a fixed-size ring, and the least recently drifted object is evicted
+// once maxObjects objects are tracked, so memory stays bounded.
+// Safe for concurrent use.
+type DriftHistory struct {
+	mu         sync.RWMutex
+	size       int
+	maxObjects int
+	sequence   uint64
+	records    map[string]*driftRing
+}
+
+// driftRing is a fixed-size ring buffer of drift records
+type driftRing struct {
+	records []DriftRecord
+	next    int
+	full    bool
+	// lastRecorded orders rings for eviction
+	lastRecorded uint64
+}
+
+// NewDriftHistory creates a drift history keeping size records per object
+func NewDriftHistory(size int) *DriftHistory {
+	if size < 1 {
+		size = DefaultDriftHistorySize
+	}
+	return &DriftHistory{
+		size:       size,
+		maxObjects: DefaultDriftHistoryObjects,
+		records:    make(map[string]*driftRing),
+	}
+}
+
+// Record stores a drift record, evicting the oldest one of the object when its ring is full
+// A new object evicts the least recently drifted object when maxObjects are tracked.
+func (h *DriftHistory) Record(record DriftRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := driftHistoryKey(record.Kind, record.Namespace, record.Name)
+	ring, exists := h.records[key]
+	if !exists {
+		if len(h.records) >= h.maxObjects {
+			h.evictLeastRecent()
+		}
+		ring = &driftRing{records: make([]DriftRecord, h.size)}
+		h.records[key] = ring
+	}
+	h.sequence++
+	ring.lastRecorded = h.sequence
+
+	ring.records[ring.next] = record
+	ring.next = (ring.next + 1) % h.size
+	if ring.next == 0 {
+		ring.full = true
+	}
+}
+
+// Get returns the drift records of an object, newest first
+// Kind matching is case-insensitive
+func (h *DriftHistory) Get(kind, namespace, name string) []DriftRecord {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ring, exists := h.records[driftHistoryKey(kind, namespace, name)]
+	if !exists {
+		return nil
+	}
+	return ring.newestFirst()
+}
+
+// List returns the drift records of all objects, newest first
+func (h *DriftHistory) List() []DriftRecord {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var all []DriftRecord
+	for _, ring := range h.records {
+		all = append(all, ring.newestFirst()...)
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Timestamp.After(all[j].Timestamp) })
+	return all
+}
+
+// evictLeastRecent removes the object whose last drift is the oldest
+// Callers must hold the write lock.
+func (h *DriftHistory) evictLeastRecent() {
+	var oldestKey string
+	var oldest uint64
+	for key, ring := range h.records {
+		if oldestKey == "" || ring.lastRecorded < oldest {
+			oldestKey, oldest = key, ring.lastRecorded
+		}
+	}
+	delete(h.records, oldestKey)
+}
+
+// newestFirst returns a copy of the ring contents, newest first
+func (r *driftRing) newestFirst() []DriftRecord {
+	count := r.next
+	if r.full {
+		count = len(r.records)
+	}
+
+	result := make([]DriftRecord, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, r.records[(r.next-i+len(r.records))%len(r.records)])
+	}
+	return result
+}
+
+// driftHistoryKey builds the per-object key
+func driftHistoryKey(kind, namespace, name string) string {
+	return strings.ToLower(kind) + "/" + namespace + "/" + name
+}
+
+// newDriftRecord converts a structuredDiff result into a drift record
+// Field values are taken from the diff, the actor of each field from the live managedFields.
+// A nil live object means the object is pending creation.
+func newDriftRecord(assetName string, desired, live *unstructured.Unstructured, diff map[string]interface{}) DriftRecord {
+	record := DriftRecord{
+		Timestamp: time.Now(),
+		Asset:     assetName,
+		Kind:      desired.GetKind(),
+		Namespace: desired.GetNamespace(),
+		Name:      desired.GetName(),
+		Action:    "update",
+	}
+	if live == nil {
+		record.Action = "create"
+	}
+
+	for _, path := range diffFieldSegments(diff) {
+		field := FieldDrift{Path: formatFieldPath(path)}
+		if leaf, ok := diffLeaf(diff, path); ok {
+			field.Live = leaf["live"]
+			field.Desired = leaf["desired"]
+		}
+		if live != nil {
+			field.Manager = FieldManagerOf(live, path)
+		}
+		record.Fields = append(record.Fields, field)
+	}
+	return record
+}
+
+// diffLeaf returns the {"live", "desired"} pair at the keys of a path in a structuredDiff result
+func diffLeaf(diff map[string]interface{}, path []string) (map[string]interface{}, bool) {
+	current := diff
+	for _, key := range path {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, isDiffLeaf(current)
+}
+
+// FieldManagerOf returns the manager that most recently wrote a field of a live object,
+// ignoring the autopilot itself. Returns "" if no other manager owns the field.
+// Lists are atomic in drift diffs, so the path never descends into list items.
+func FieldManagerOf(live *unstructured.Unstructured, path []string) string {
+	manager := ""
+	var latest time.Time
+	for _, entry := range live.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if !ownsFieldPath(fields, path) {
+			continue
+		}
+
+		var written time.Time
+		if entry.Time != nil {
+			written = entry.Time.Time
+		}
+		if manager == "" || written.After(latest) {
+			manager = entry.Manager
+			latest = written
+		}
+	}
+	return manager
+}
+
+// ownsFieldPath reports whether a FieldsV1 set contains a path or any field below it
+func ownsFieldPath(fields map[string]interface{}, path []string) bool {
+	current := fields
+	for _, key := range path {
+		next, ok := current["f:"+key].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDriftHistoryRing(t *testing.T) {
+	history := NewDriftHistory(2)
+	base := time.Now()
+
+	for i := 0; i < 3; i++ {
+		history.Record(DriftRecord{
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			Kind:      "ConfigMap",
+			Namespace: "default",
+			Name:      "test",
+			Action:    "update",
+		})
+	}
+	history.Record(DriftRecord{Timestamp: base.Add(10 * time.Second), Kind: "Secret", Namespace: "default", Name: "other"})
+
+	records := history.Get("configmap", "default", "test")
+	if len(records) != 2 {
+		t.Fatalf("Get() returned %d records, want 2 (oldest evicted)", len(records))
+	}
+	if !records[0].Timestamp.Equal(base.Add(2*time.Second)) || !records[1].Timestamp.Equal(base.Add(time.Second)) {
+		t.Errorf("Get() records not newest first: %v, %v", records[0].Timestamp, records[1].Timestamp)
+	}
+
+	if got := history.Get("ConfigMap", "default", "missing"); got != nil {
+		t.Errorf("Get() of unknown object = %v, want nil", got)
+	}
+
+	all := history.List()
+	if len(all) != 3 {
+		t.Fatalf("List() returned %d records, want 3", len(all))
+	}
+	if all[0].Kind != "Secret" {
+		t.Errorf("List()[0].Kind = %s, want newest record (Secret)", all[0].Kind)
+	}
+}
+
+func TestDriftHistoryEvictsLeastRecentObject(t *testing.T) {
+	history := NewDriftHistory(2)
+	history.maxObjects = 2
+
+	record := func(name string) {
+		history.Record(DriftRecord{Timestamp: time.Now(), Kind: "ConfigMap", Namespace: "default", Name: name})
+	}
+	record("a")
+	record("b")
+	record("a") // b is now the least recently drifted object
+	record("c")
+
+	if got := history.Get("ConfigMap", "default", "b"); got != nil {
+		t.Errorf("Get(b) = %v, want evicted", got)
+	}
+	if got := history.Get("ConfigMap", "default", "a"); len(got) != 2 {
+		t.Errorf("Get(a) returned %d records, want 2", len(got))
+	}
+	if got := history.Get("ConfigMap", "default", "c"); len(got) != 1 {
+		t.Errorf("Get(c) returned %d records, want 1", len(got))
+	}
+	if len(history.records) != 2 {
+		t.Errorf("tracked %d objects, want 2", len(history.records))
+	}
+}
+
+func TestNewDriftRecord(t *testing.T) {
+	desired := makeObj(nil, map[string]interface{}{"key": "desired"})
+	live := makeObj(nil, map[string]interface{}{"key": "live"})
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:  FieldManager,
+			Time:     &metav1.Time{Time: time.Now()},
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:key":{}}}`)},
+		},
+		{
+			Manager:  "argocd-controller",
+			Time:     &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:key":{}}}`)},
+		},
+	})
+
+	record := newDriftRecord("test-asset", desired, live, structuredDiff(sanitizeObject(live), sanitizeObject(desired)))
+	if record.Action != "update" || record.Asset != "test-asset" || record.Kind != "ConfigMap" {
+		t.Errorf("newDriftRecord() = %+v", record)
+	}
+	if len(record.Fields) != 1 {
+		t.Fatalf("newDriftRecord() fields = %+v, want one", record.Fields)
+	}
+
+	field := record.Fields[0]
+	if field.Path != "spec.key" || field.Live != "live" || field.Desired != "desired" {
+		t.Errorf("newDriftRecord() field = %+v", field)
+	}
+	if field.Manager != "argocd-controller" {
+		t.Errorf("newDriftRecord() field manager = %q, want argocd-controller", field.Manager)
+	}
+
+	created := newDriftRecord("test-asset", desired, nil, structuredDiff(nil, sanitizeObject(desired)))
+	if created.Action != "create" {
+		t.Errorf("newDriftRecord() of missing live object action = %s, want create", created.Action)
+	}
+}
+
+func TestNewDriftRecordDottedLabelManager(t *testing.T) {
+	desired := makeObj(map[string]string{"app.kubernetes.io/managed-by": "virt-platform-autopilot"}, nil)
+	live := makeObj(map[string]string{"app.kubernetes.io/managed-by": "argocd"}, nil)
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:  "argocd-controller",
+			Time:     &metav1.Time{Time: time.Now()},
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}}}}`)},
+		},
+	})
+
+	record := newDriftRecord("test-asset", desired, live, structuredDiff(sanitizeObject(live), sanitizeObject(desired)))
+	if len(record.Fields) != 1 {
+		t.Fatalf("newDriftRecord() fields = %+v, want one", record.Fields)
+	}
+	if manager := record.Fields[0].Manager; manager != "argocd-controller" {
+		t.Errorf("newDriftRecord() field manager = %q, want argocd-controller", manager)
+	}
+}
+
+func TestCompetingManagers(t *testing.T) {
+	record := DriftRecord{
+		Fields: []FieldDrift{
+			{Path: "spec.a", Manager: "kubectl-edit"},
+			{Path: "spec.b", Manager: "argocd-controller"},
+			{Path: "spec.c", Manager: "argocd-controller"},
+			{Path: "spec.d"},
+		},
+	}
+
+	competitors := record.CompetingManagers()
+	if len(competitors) != 2 {
+		t.Fatalf("CompetingManagers() = %+v, want two managers", competitors)
+	}
+	if competitors[0].Manager != "argocd-controller" || len(competitors[0].Fields) != 2 {
+		t.Errorf("CompetingManagers()[0] = %+v, want argocd-controller owning two fields", competitors[0])
+	}
+
+	want := "argocd-controller (spec.b, spec.c); kubectl-edit (spec.a)"
+	if got := summarizeCompetitors(competitors); got != want {
+		t.Errorf("summarizeCompetitors() = %q, want %q", got, want)
+	}
+	if got := summarizeCompetitors((&DriftRecord{}).CompetingManagers()); got != "" {
+		t.Errorf("summarizeCompetitors() without managers = %q, want empty", got)
+	}
+}
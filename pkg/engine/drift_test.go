@@ -253,3 +253,98 @@ func TestCompareSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffFieldPaths(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "test"},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{"image": "old"},
+			"removed":  "x",
+		},
+	}
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "test"},
+		"spec": map[string]interface{}{
+			"replicas": int64(5),
+			"template": map[string]interface{}{"image": "new"},
+			"added":    map[string]interface{}{"live": "not a leaf marker", "other": true},
+		},
+	}
+
+	got := DiffFieldPaths(structuredDiff(live, desired))
+	want := []string{"spec.added", "spec.removed", "spec.replicas", "spec.template.image"}
+	if len(got) != len(want) {
+		t.Fatalf("DiffFieldPaths() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DiffFieldPaths()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if paths := DiffFieldPaths(structuredDiff(live, live)); len(paths) != 0 {
+		t.Errorf("DiffFieldPaths() of identical objects = %v, want none", paths)
+	}
+}
+
+func TestDiffFieldPathsDottedKeys(t *testing.T) {
+	desired := makeObj(map[string]string{"app.kubernetes.io/managed-by": "virt-platform-autopilot"}, nil)
+	desired.SetAnnotations(map[string]string{"platform.kubevirt.io/mode": "managed"})
+	live := makeObj(map[string]string{"app.kubernetes.io/managed-by": "argocd"}, nil)
+	live.SetAnnotations(map[string]string{"platform.kubevirt.io/mode": "unmanaged"})
+
+	diff := structuredDiff(sanitizeObject(live), sanitizeObject(desired))
+	got := DiffFieldPaths(diff)
+	want := []string{
+		"metadata.annotations[platform.kubevirt.io/mode]",
+		"metadata.labels[app.kubernetes.io/managed-by]",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DiffFieldPaths() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DiffFieldPaths()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// The values are found under the unsplit label and annotation keys
+	record := newDriftRecord("test-asset", desired, live, diff)
+	if len(record.Fields) != 2 {
+		t.Fatalf("newDriftRecord() fields = %+v, want two", record.Fields)
+	}
+	if field := record.Fields[1]; field.Live != "argocd" || field.Desired != "virt-platform-autopilot" {
+		t.Errorf("label drift = %+v, want argocd -> virt-platform-autopilot", field)
+	}
+	if field := record.Fields[0]; field.Live != "unmanaged" || field.Desired != "managed" {
+		t.Errorf("annotation drift = %+v, want unmanaged -> managed", field)
+	}
+}
+
+func TestDiffMissingLive(t *testing.T) {
+	dd := &DriftDetector{} // no dry-run for a missing live object
+
+	desired := makeObj(map[string]string{"app": "test"}, map[string]interface{}{"key": "value"})
+	paths := DiffFieldPaths(dd.Diff(t.Context(), desired, nil))
+
+	want := []string{"apiVersion", "kind", "metadata", "spec"}
+	if len(paths) != len(want) {
+		t.Fatalf("Diff() paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("Diff() paths[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestSummarizePaths(t *testing.T) {
+	paths := []string{"a", "b", "c", "d"}
+	if got := summarizePaths(paths, 5); got != "a, b, c, d" {
+		t.Errorf("summarizePaths() = %q", got)
+	}
+	if got := summarizePaths(paths, 2); got != "a, b and 2 more" {
+		t.Errorf("summarizePaths() = %q", got)
+	}
+}
@@ -0,0 +1,421 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+const (
+	// nfdLabelPrefix is the prefix of all Node Feature Discovery labels
+	nfdLabelPrefix = "feature.node.kubernetes.io/"
+
+	// nfdPCILabelPrefix is the prefix of NFD PCI device labels (pci-<class>_<vendor>.present)
+	nfdPCILabelPrefix = nfdLabelPrefix + "pci-"
+
+	// nfdUSBLabelPrefix is the prefix of NFD USB device labels (usb-<class>_<vendor>_<device>.present)
+	nfdUSBLabelPrefix = nfdLabelPrefix + "usb-"
+
+	// nfdCPUVendorLabel reports the CPU vendor string (e.g. GenuineIntel, AuthenticAMD)
+	nfdCPUVendorLabel = nfdLabelPrefix + "cpu-model.vendor_id"
+
+	// nfdMemoryNUMALabel is "true" when NFD detects a NUMA architecture
+	nfdMemoryNUMALabel = nfdLabelPrefix + "memory-numa"
+
+	// nfdNUMANodeCountLabel carries the NUMA node count when published by a NodeFeatureRule
+	nfdNUMANodeCountLabel = nfdLabelPrefix + "memory-numa.node_count"
+
+	// nfdMultithreadingLabel is "true" when SMT (hyperthreading) is enabled
+	nfdMultithreadingLabel = nfdLabelPrefix + "cpu-hardware_multithreading"
+
+	// nodeRoleLabelPrefix is the prefix of node role labels
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+	// bmcAddressAnnotation is the node's BMC address, used as the fence agent's --ip
+	bmcAddressAnnotation = "platform.kubevirt.io/bmc-address"
+
+	// bmcPortAnnotation is the node's BMC port, used as the fence agent's --ipport
+	bmcPortAnnotation = "platform.kubevirt.io/bmc-port"
+)
+
+var (
+	// gpuResourceVendors maps GPU device plugin resources to vendor names
+	gpuResourceVendors = map[corev1.ResourceName]string{
+		"nvidia.com/gpu":     "nvidia",
+		"amd.com/gpu":        "amd",
+		"gpu.intel.com/i915": "intel",
+	}
+
+	// gpuPCIVendors maps PCI display classes and vendor IDs to GPU vendor names
+	// Class 0300 (VGA-compatible) only counts for vendors of discrete GPUs: Intel VGA
+	// devices are integrated graphics, and BMC VGA devices come from other vendors.
+	// Class 0302 (3D controller) is used by datacenter GPUs.
+	gpuPCIVendors = map[string]map[string]string{
+		"0300": {
+			"10de": "nvidia",
+			"1002": "amd",
+		},
+		"0302": {
+			"10de": "nvidia",
+			"1002": "amd",
+			"8086": "intel",
+		},
+	}
+)
+
+// RenderContextBuilder builds RenderContext from cluster state
+type RenderContextBuilder struct {
+	client        client.Client
+	eventRecorder *util.EventRecorder
+}
+
+// NewRenderContextBuilder creates a new RenderContext builder
+func NewRenderContextBuilder(c client.Client) *RenderContextBuilder {
+	return &RenderContextBuilder{
+		client: c,
+	}
+}
+
+// SetEventRecorder sets the event recorder for hardware detection events
+func (b *RenderContextBuilder) SetEventRecorder(recorder *util.EventRecorder) {
+	b.eventRecorder = recorder
+}
+
+// Build constructs a RenderContext from the current HCO state
+func (b *RenderContextBuilder) Build(ctx context.Context, hco *unstructured.Unstructured) (*pkgcontext.RenderContext, error) {
+	logger := log.FromContext(ctx)
+
+	if hco == nil {
+		return nil, fmt.Errorf("HCO object is nil")
+	}
+
+	// Detect hardware capabilities
+	hardware, nodes, err := b.detectHardware(ctx)
+	if err != nil {
+		logger.Error(err, "Hardware detection failed, using defaults",
+			"hco", hco.GetName())
+
+		// Emit warning event with specific reason
+		if b.eventRecorder != nil {
+			b.eventRecorder.HardwareDetectionFailed(hco, err.Error())
+		}
+
+		// Use defaults - don't fail reconciliation
+		hardware = &pkgcontext.HardwareContext{}
+		nodes = nil
+	}
+
+	// Invalid device allowlists render as empty, surface why
+	if allowlist, exists := hco.GetAnnotations()[pkgcontext.VFIODevicesAnnotation]; exists {
+		if _, err := pkgcontext.ParsePCIDeviceAllowlist(allowlist); err != nil {
+			logger.Error(err, "Invalid VFIO device allowlist, no devices will be assigned",
+				"annotation", pkgcontext.VFIODevicesAnnotation)
+		}
+	}
+	if allowlist, exists := hco.GetAnnotations()[pkgcontext.USBDevicesAnnotation]; exists {
+		if _, err := pkgcontext.ParseUSBDeviceAllowlist(allowlist); err != nil {
+			logger.Error(err, "Invalid USB device allowlist, no devices will be permitted",
+				"annotation", pkgcontext.USBDevicesAnnotation)
+		}
+	}
+	if value, exists := hco.GetAnnotations()[pkgcontext.MigrationNetworkAnnotation]; exists {
+		if _, err := pkgcontext.ParseMigrationNetwork(value); err != nil {
+			logger.Error(err, "Invalid migration network, live migration stays on the pod network",
+				"annotation", pkgcontext.MigrationNetworkAnnotation)
+		}
+	}
+
+	return &pkgcontext.RenderContext{
+		HCO:      hco,
+		Hardware: hardware,
+		Nodes:    nodes,
+	}, nil
+}
+
+// detectHardware queries the cluster to detect hardware capabilities
+// Returns the cluster-wide summary along with the per-node inventory it was derived from
+func (b *RenderContextBuilder) detectHardware(ctx context.Context) (*pkgcontext.HardwareContext, []pkgcontext.NodeInfo, error) {
+	hardware := &pkgcontext.HardwareContext{}
+
+	// List all nodes to examine hardware
+	nodeList := &corev1.NodeList{}
+	if err := b.client.List(ctx, nodeList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// Build per-node inventory and fold it into the cluster-wide summary
+	nodes := make([]pkgcontext.NodeInfo, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		info := buildNodeInfo(&nodeList.Items[i])
+		nodes = append(nodes, info)
+
+		hardware.PCIDevicesPresent = hardware.PCIDevicesPresent || info.PCIDevicesPresent
+		hardware.NUMANodesPresent = hardware.NUMANodesPresent || info.NUMANodesPresent
+		hardware.VFIOCapable = hardware.VFIOCapable || info.IOMMUEnabled
+		hardware.USBDevicesPresent = hardware.USBDevicesPresent || info.USBDevicesPresent
+		hardware.GPUPresent = hardware.GPUPresent || len(info.GPUVendors) > 0
+	}
+
+	return hardware, nodes, nil
+}
+
+// buildNodeInfo collects the hardware inventory of a single node
+func buildNodeInfo(node *corev1.Node) pkgcontext.NodeInfo {
+	info := pkgcontext.NodeInfo{
+		Name:              node.Name,
+		Roles:             nodeRoles(node),
+		CPUVendor:         pkgcontext.NormalizeCPUVendor(node.Labels[nfdCPUVendorLabel]),
+		CPUVendorID:       node.Labels[nfdCPUVendorLabel],
+		NUMANodes:         numaNodeCount(node),
+		CPUs:              node.Status.Capacity.Cpu().Value(),
+		ThreadsPerCore:    threadsPerCore(node),
+		Memory:            node.Status.Capacity.Memory().Value(),
+		IOMMUEnabled:      hasVFIOCapability(node),
+		GPUVendors:        gpuVendors(node),
+		PCIDevices:        pciDeviceIDs(node),
+		USBDevices:        usbDeviceIDs(node),
+		DevicePlugins:     devicePluginResources(node),
+		HugePages:         hugePageCapacity(node),
+		BMCAddress:        node.Annotations[bmcAddressAnnotation],
+		BMCPort:           node.Annotations[bmcPortAnnotation],
+		NFDLabels:         nfdLabels(node),
+		PCIDevicesPresent: hasPCIDevices(node),
+		NUMANodesPresent:  hasNUMATopology(node),
+		USBDevicesPresent: hasUSBDevices(node),
+	}
+
+	return info
+}
+
+// hasPCIDevices checks if node has PCI devices suitable for passthrough
+func hasPCIDevices(node *corev1.Node) bool {
+	// Check for common PCI device labels/annotations
+	// In real implementation, this would check for specific device labels
+	// or examine node status for device plugins
+	if _, exists := node.Labels["feature.node.kubernetes.io/pci-present"]; exists {
+		return true
+	}
+
+	// Check capacity for device plugins (e.g., nvidia.com/gpu)
+	return len(devicePluginResources(node)) > 0
+}
+
+// hasNUMATopology checks if node has NUMA topology
+func hasNUMATopology(node *corev1.Node) bool {
+	// Check for NUMA-related labels
+	if _, exists := node.Labels[nfdMultithreadingLabel]; exists {
+		return true
+	}
+
+	// Check annotations for topology manager policy
+	if policy, exists := node.Annotations["kubevirt.io/topology-manager-policy"]; exists && policy != "" {
+		return true
+	}
+
+	return false
+}
+
+// hasVFIOCapability checks if node supports VFIO (IOMMU enabled)
+func hasVFIOCapability(node *corev1.Node) bool {
+	// Check for IOMMU-related labels
+	if iommu, exists := node.Labels["feature.node.kubernetes.io/iommu-enabled"]; exists && iommu == "true" {
+		return true
+	}
+
+	return false
+}
+
+// hasUSBDevices checks if node has USB devices
+func hasUSBDevices(node *corev1.Node) bool {
+	// Check for USB device labels
+	if _, exists := node.Labels["feature.node.kubernetes.io/usb-present"]; exists {
+		return true
+	}
+
+	// Per-device labels published by NFD's usb source
+	return len(usbDeviceIDs(node)) > 0
+}
+
+// gpuVendors returns the sorted list of GPU vendors present on a node
+// Vendors are detected from device plugin resources and from NFD PCI display-class labels
+func gpuVendors(node *corev1.Node) []string {
+	found := make(map[string]bool)
+
+	// Device plugin resources (GPU operator installed)
+	for resource, vendor := range gpuResourceVendors {
+		if _, exists := node.Status.Capacity[resource]; exists {
+			found[vendor] = true
+		}
+	}
+
+	// NFD PCI labels: feature.node.kubernetes.io/pci-<class>_<vendor>.present
+	for label := range node.Labels {
+		if !strings.HasPrefix(label, nfdPCILabelPrefix) {
+			continue
+		}
+		classVendor := strings.TrimSuffix(strings.TrimPrefix(label, nfdPCILabelPrefix), ".present")
+		class, vendorID, ok := strings.Cut(classVendor, "_")
+		if !ok {
+			continue
+		}
+		// Strip the device ID when NFD is configured with deviceLabelFields [class, vendor, device]
+		vendorID, _, _ = strings.Cut(vendorID, "_")
+		if vendor, known := gpuPCIVendors[class][vendorID]; known {
+			found[vendor] = true
+		}
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	vendors := make([]string, 0, len(found))
+	for vendor := range found {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+	return vendors
+}
+
+// pciDeviceIDs returns the sorted PCI vendor:device IDs present on a node
+// Requires NFD deviceLabelFields [class, vendor, device], which publishes
+// feature.node.kubernetes.io/pci-<class>_<vendor>_<device>.present labels
+func pciDeviceIDs(node *corev1.Node) []string {
+	return nfdDeviceIDs(node, nfdPCILabelPrefix)
+}
+
+// usbDeviceIDs returns the sorted USB vendor:product IDs present on a node
+// NFD's default USB deviceLabelFields are [class, vendor, device]
+func usbDeviceIDs(node *corev1.Node) []string {
+	return nfdDeviceIDs(node, nfdUSBLabelPrefix)
+}
+
+// nfdDeviceIDs parses <prefix><class>_<vendor>_<device>.present labels into vendor:device IDs
+func nfdDeviceIDs(node *corev1.Node, prefix string) []string {
+	var ids []string
+	for label, value := range node.Labels {
+		fields, ok := strings.CutPrefix(label, prefix)
+		if !ok || value != "true" {
+			continue
+		}
+		fields, ok = strings.CutSuffix(fields, ".present")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(fields, "_")
+		if len(parts) != 3 {
+			continue
+		}
+		ids = append(ids, strings.ToLower(parts[1]+":"+parts[2]))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// nodeRoles returns the sorted roles from node-role.kubernetes.io/<role> labels
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// threadsPerCore returns the hardware threads per physical core
+// NFD only reports whether SMT is enabled, and x86 SMT is always 2-way
+func threadsPerCore(node *corev1.Node) int {
+	if node.Labels[nfdMultithreadingLabel] == "true" {
+		return 2
+	}
+	return 1
+}
+
+// numaNodeCount returns the number of NUMA nodes reported by NFD
+// The exact count comes from a NodeFeatureRule exposing memory.numa node_count.
+// The memory-numa boolean only tells a UMA node (1) apart, a NUMA node without
+// the count is reported as unknown (0).
+func numaNodeCount(node *corev1.Node) int {
+	if raw, exists := node.Labels[nfdNUMANodeCountLabel]; exists {
+		if count, err := strconv.Atoi(raw); err == nil && count > 0 {
+			return count
+		}
+	}
+
+	if node.Labels[nfdMemoryNUMALabel] == "false" {
+		return 1
+	}
+	return 0
+}
+
+// devicePluginResources returns the extended resources advertised on a node
+// Standard resources (cpu, memory, pods, storage, hugepages) are excluded
+func devicePluginResources(node *corev1.Node) map[string]int64 {
+	resources := make(map[string]int64)
+	for name, quantity := range node.Status.Capacity {
+		if isStandardResource(string(name)) {
+			continue
+		}
+		resources[string(name)] = quantity.Value()
+	}
+	return resources
+}
+
+// hugePageCapacity returns hugepage capacity in bytes keyed by page size
+func hugePageCapacity(node *corev1.Node) map[string]int64 {
+	hugePages := make(map[string]int64)
+	for name, quantity := range node.Status.Capacity {
+		if pageSize, ok := strings.CutPrefix(string(name), corev1.ResourceHugePagesPrefix); ok {
+			hugePages[pageSize] = quantity.Value()
+		}
+	}
+	return hugePages
+}
+
+// nfdLabels returns the Node Feature Discovery labels of a node
+func nfdLabels(node *corev1.Node) map[string]string {
+	labels := make(map[string]string)
+	for key, value := range node.Labels {
+		if strings.HasPrefix(key, nfdLabelPrefix) {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// isStandardResource checks if a resource name is a built-in Kubernetes resource
+func isStandardResource(name string) bool {
+	switch corev1.ResourceName(name) {
+	case corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourcePods, corev1.ResourceEphemeralStorage:
+		return true
+	}
+	return strings.HasPrefix(name, corev1.ResourceHugePagesPrefix)
+}
@@ -0,0 +1,764 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+func TestHasPCIDevices(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "has PCI device label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-present": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "has GPU device in capacity",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("1"),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "has custom device plugin",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"intel.com/qat": resource.MustParse("2"),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "only standard resources",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"cpu":               resource.MustParse("4"),
+						"memory":            resource.MustParse("8Gi"),
+						"pods":              resource.MustParse("110"),
+						"ephemeral-storage": resource.MustParse("100Gi"),
+						"hugepages-1Gi":     resource.MustParse("0"),
+						"hugepages-2Mi":     resource.MustParse("0"),
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no labels or capacity",
+			node: &corev1.Node{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hasPCIDevices(tt.node)
+			if got != tt.want {
+				t.Errorf("hasPCIDevices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasNUMATopology(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "has CPU multithreading label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/cpu-hardware_multithreading": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "has topology manager annotation",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubevirt.io/topology-manager-policy": "single-numa-node",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "empty topology manager annotation",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubevirt.io/topology-manager-policy": "",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no NUMA indicators",
+			node: &corev1.Node{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hasNUMATopology(tt.node)
+			if got != tt.want {
+				t.Errorf("hasNUMATopology() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasVFIOCapability(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "IOMMU enabled",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/iommu-enabled": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "IOMMU explicitly disabled",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/iommu-enabled": "false",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no IOMMU label",
+			node: &corev1.Node{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hasVFIOCapability(tt.node)
+			if got != tt.want {
+				t.Errorf("hasVFIOCapability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasUSBDevices(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "has USB present label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/usb-present": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "has NFD USB device label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/usb-ff_0529_0001.present": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "no USB label",
+			node: &corev1.Node{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hasUSBDevices(tt.node)
+			if got != tt.want {
+				t.Errorf("hasUSBDevices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGPUVendors(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want []string
+	}{
+		{
+			name: "from device plugin capacity",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"nvidia.com/gpu":     resource.MustParse("2"),
+						"gpu.intel.com/i915": resource.MustParse("1"),
+					},
+				},
+			},
+			want: []string{"intel", "nvidia"},
+		},
+		{
+			name: "AMD device plugin",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"amd.com/gpu": resource.MustParse("1"),
+					},
+				},
+			},
+			want: []string{"amd"},
+		},
+		{
+			name: "no GPU",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"cpu":    resource.MustParse("4"),
+						"memory": resource.MustParse("8Gi"),
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "from NFD PCI labels",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-0302_10de.present": "true",
+						"feature.node.kubernetes.io/pci-0300_1002.present": "true",
+					},
+				},
+			},
+			want: []string{"amd", "nvidia"},
+		},
+		{
+			name: "Intel VGA device is integrated graphics",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-0300_8086.present": "true",
+						"feature.node.kubernetes.io/pci-0300_1a03.present": "true",
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Intel 3D controller",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-0302_8086_0bd5.present": "true",
+					},
+				},
+			},
+			want: []string{"intel"},
+		},
+		{
+			name: "non-GPU PCI class ignored",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-0200_8086.present": "true",
+					},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gpuVendors(tt.node)
+			if len(got) != len(tt.want) {
+				t.Fatalf("gpuVendors() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("gpuVendors() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPCIDeviceIDs(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/pci-0302_10de_1eb8.present": "true",
+				"feature.node.kubernetes.io/pci-0300_1002_7408.present": "true",
+				"feature.node.kubernetes.io/pci-0200_8086_1572.present": "false",
+				"feature.node.kubernetes.io/pci-0300_10de.present":      "true",
+				"feature.node.kubernetes.io/pci-present":                "true",
+			},
+		},
+	}
+
+	got := pciDeviceIDs(node)
+	want := []string{"1002:7408", "10de:1eb8"}
+	if len(got) != len(want) {
+		t.Fatalf("pciDeviceIDs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pciDeviceIDs() = %v, want %v", got, want)
+		}
+	}
+
+	// Device-level labels must still count toward GPU vendor detection
+	if vendors := gpuVendors(node); len(vendors) != 2 {
+		t.Errorf("gpuVendors() = %v, want [amd nvidia]", vendors)
+	}
+}
+
+func TestUSBDeviceIDs(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/usb-ff_0529_0001.present":   "true",
+				"feature.node.kubernetes.io/usb-0e_046D_0825.present":   "true",
+				"feature.node.kubernetes.io/pci-0300_10de_1eb8.present": "true",
+			},
+		},
+	}
+
+	got := usbDeviceIDs(node)
+	if len(got) != 2 || got[0] != "046d:0825" || got[1] != "0529:0001" {
+		t.Errorf("usbDeviceIDs() = %v, want [046d:0825 0529:0001]", got)
+	}
+}
+
+func TestNUMANodeCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   int
+	}{
+		{"explicit node count", map[string]string{"feature.node.kubernetes.io/memory-numa.node_count": "4"}, 4},
+		{"invalid node count falls back", map[string]string{
+			"feature.node.kubernetes.io/memory-numa.node_count": "bogus",
+			"feature.node.kubernetes.io/memory-numa":            "true",
+		}, 0},
+		{"multi-NUMA label without count", map[string]string{"feature.node.kubernetes.io/memory-numa": "true"}, 0},
+		{"UMA", map[string]string{"feature.node.kubernetes.io/memory-numa": "false"}, 1},
+		{"unknown", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := numaNodeCount(node); got != tt.want {
+				t.Errorf("numaNodeCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildNodeInfo(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-0",
+			Labels: map[string]string{
+				"node-role.kubernetes.io/worker":                         "",
+				"feature.node.kubernetes.io/cpu-model.vendor_id":         "AuthenticAMD",
+				"feature.node.kubernetes.io/iommu-enabled":               "true",
+				"feature.node.kubernetes.io/memory-numa.node_count":      "2",
+				"feature.node.kubernetes.io/cpu-hardware_multithreading": "true",
+				"kubernetes.io/hostname":                                 "worker-0",
+			},
+			Annotations: map[string]string{
+				"platform.kubevirt.io/bmc-address": "10.0.0.10",
+				"platform.kubevirt.io/bmc-port":    "623",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				"cpu":            resource.MustParse("64"),
+				"memory":         resource.MustParse("256Gi"),
+				"hugepages-1Gi":  resource.MustParse("8Gi"),
+				"nvidia.com/gpu": resource.MustParse("1"),
+			},
+		},
+	}
+
+	info := buildNodeInfo(node)
+
+	if info.Name != "worker-0" {
+		t.Errorf("Name = %q, want worker-0", info.Name)
+	}
+	if !info.HasRole("worker") || len(info.Roles) != 1 {
+		t.Errorf("Roles = %v, want [worker]", info.Roles)
+	}
+	if info.CPUVendor != "amd" || info.CPUVendorID != "AuthenticAMD" {
+		t.Errorf("CPUVendor = %q, CPUVendorID = %q, want amd/AuthenticAMD", info.CPUVendor, info.CPUVendorID)
+	}
+	if info.NUMANodes != 2 {
+		t.Errorf("NUMANodes = %d, want 2", info.NUMANodes)
+	}
+	if info.CPUs != 64 || info.ThreadsPerCore != 2 || info.Memory != 256*1024*1024*1024 {
+		t.Errorf("CPUs/ThreadsPerCore/Memory = %d/%d/%d, want 64/2/256Gi", info.CPUs, info.ThreadsPerCore, info.Memory)
+	}
+	if !info.IOMMUEnabled {
+		t.Error("IOMMUEnabled = false, want true")
+	}
+	if len(info.GPUVendors) != 1 || info.GPUVendors[0] != "nvidia" {
+		t.Errorf("GPUVendors = %v, want [nvidia]", info.GPUVendors)
+	}
+	if info.DevicePlugins["nvidia.com/gpu"] != 1 || len(info.DevicePlugins) != 1 {
+		t.Errorf("DevicePlugins = %v, want only nvidia.com/gpu=1", info.DevicePlugins)
+	}
+	if info.HugePages["1Gi"] != 8*1024*1024*1024 {
+		t.Errorf("HugePages[1Gi] = %d, want 8Gi", info.HugePages["1Gi"])
+	}
+	if info.BMCAddress != "10.0.0.10" || info.BMCPort != "623" {
+		t.Errorf("BMCAddress/BMCPort = %q/%q, want 10.0.0.10/623", info.BMCAddress, info.BMCPort)
+	}
+	if _, exists := info.NFDLabels["kubernetes.io/hostname"]; exists {
+		t.Error("NFDLabels should only contain NFD labels")
+	}
+	if info.NFDLabels["feature.node.kubernetes.io/iommu-enabled"] != "true" {
+		t.Error("NFDLabels missing iommu-enabled label")
+	}
+}
+
+func TestNewRenderContextBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	t.Run("creates builder successfully", func(t *testing.T) {
+		builder := NewRenderContextBuilder(fakeClient)
+
+		if builder == nil {
+			t.Fatal("NewRenderContextBuilder() returned nil")
+		}
+
+		if builder.client != fakeClient {
+			t.Error("NewRenderContextBuilder() did not set client correctly")
+		}
+	})
+}
+
+func TestRenderContextBuilder_SetEventRecorder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	builder := NewRenderContextBuilder(fakeClient)
+
+	t.Run("sets event recorder", func(t *testing.T) {
+		recorder := &util.EventRecorder{}
+		builder.SetEventRecorder(recorder)
+
+		if builder.eventRecorder != recorder {
+			t.Error("SetEventRecorder() did not set event recorder")
+		}
+	})
+}
+
+func TestRenderContextBuilder_Build(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("builds context successfully with hardware detection", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = corev1.AddToScheme(scheme)
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node",
+				Labels: map[string]string{
+					"feature.node.kubernetes.io/pci-present": "true",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("1"),
+				},
+			},
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(node).
+			Build()
+
+		builder := NewRenderContextBuilder(fakeClient)
+
+		hco := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "hco.kubevirt.io/v1beta1",
+				"kind":       "HyperConverged",
+				"metadata": map[string]interface{}{
+					"name":      "test-hco",
+					"namespace": "test-namespace",
+				},
+			},
+		}
+
+		renderCtx, err := builder.Build(ctx, hco)
+
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		if renderCtx == nil {
+			t.Fatal("Build() returned nil context")
+		}
+
+		if renderCtx.HCO != hco {
+			t.Error("Build() did not set HCO correctly")
+		}
+
+		if renderCtx.Hardware == nil {
+			t.Fatal("Build() did not set hardware context")
+		}
+
+		if !renderCtx.Hardware.GPUPresent {
+			t.Error("Build() did not detect GPU hardware")
+		}
+
+		if !renderCtx.Hardware.PCIDevicesPresent {
+			t.Error("Build() did not detect PCI devices")
+		}
+
+		if len(renderCtx.Nodes) != 1 || renderCtx.Nodes[0].Name != "test-node" {
+			t.Fatalf("Build() Nodes = %v, want single test-node entry", renderCtx.Nodes)
+		}
+	})
+
+	t.Run("returns error when HCO is nil", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = corev1.AddToScheme(scheme)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		builder := NewRenderContextBuilder(fakeClient)
+
+		_, err := builder.Build(ctx, nil)
+
+		if err == nil {
+			t.Error("Build() should return error when HCO is nil")
+		}
+	})
+}
+
+func TestDetectHardware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GPU detection", func(t *testing.T) {
+		testGPUDetection(ctx, t)
+	})
+
+	t.Run("other hardware detection", func(t *testing.T) {
+		testOtherHardwareDetection(ctx, t)
+	})
+
+	t.Run("handles empty node list", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = corev1.AddToScheme(scheme)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		builder := NewRenderContextBuilder(fakeClient)
+		hardware, _, err := builder.detectHardware(ctx)
+
+		if err != nil {
+			t.Fatalf("detectHardware() error = %v", err)
+		}
+		if hardware.GPUPresent || hardware.PCIDevicesPresent ||
+			hardware.NUMANodesPresent || hardware.VFIOCapable ||
+			hardware.USBDevicesPresent {
+			t.Error("detectHardware() detected hardware on empty node list")
+		}
+	})
+
+	t.Run("returns error when client fails", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = corev1.AddToScheme(scheme)
+		fakeClient := &failingClient{
+			Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		}
+
+		builder := NewRenderContextBuilder(fakeClient)
+		_, _, err := builder.detectHardware(ctx)
+
+		if err == nil {
+			t.Error("detectHardware() should return error when client fails")
+		}
+	})
+}
+
+func testGPUDetection(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	gpuTests := []struct {
+		name         string
+		resourceName string
+	}{
+		{"nvidia", "nvidia.com/gpu"},
+		{"AMD", "amd.com/gpu"},
+		{"Intel", "gpu.intel.com/i915"},
+	}
+
+	for _, tt := range gpuTests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"},
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceName(tt.resourceName): *newQuantity(1),
+					},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+			builder := NewRenderContextBuilder(fakeClient)
+			hardware, _, err := builder.detectHardware(ctx)
+
+			if err != nil {
+				t.Fatalf("detectHardware() error = %v", err)
+			}
+			if !hardware.GPUPresent {
+				t.Errorf("detectHardware() did not detect %s GPU", tt.name)
+			}
+		})
+	}
+}
+
+func testOtherHardwareDetection(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name      string
+		label     string
+		checkFunc func(*pkgcontext.HardwareContext) bool
+	}{
+		{"PCI devices", "feature.node.kubernetes.io/pci-present", func(h *pkgcontext.HardwareContext) bool { return h.PCIDevicesPresent }},
+		{"NUMA topology", "feature.node.kubernetes.io/cpu-hardware_multithreading", func(h *pkgcontext.HardwareContext) bool { return h.NUMANodesPresent }},
+		{"VFIO capability", "feature.node.kubernetes.io/iommu-enabled", func(h *pkgcontext.HardwareContext) bool { return h.VFIOCapable }},
+		{"USB devices", "feature.node.kubernetes.io/usb-present", func(h *pkgcontext.HardwareContext) bool { return h.USBDevicesPresent }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-node",
+					Labels: map[string]string{tt.label: "true"},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+			builder := NewRenderContextBuilder(fakeClient)
+			hardware, _, err := builder.detectHardware(ctx)
+
+			if err != nil {
+				t.Fatalf("detectHardware() error = %v", err)
+			}
+			if !tt.checkFunc(hardware) {
+				t.Errorf("detectHardware() did not detect %s", tt.name)
+			}
+		})
+	}
+}
+
+// Helper to create resource quantities
+func newQuantity(value int64) *resource.Quantity {
+	q := resource.Quantity{}
+	q.Set(value)
+	return &q
+}
+
+// failingClient wraps a client to simulate failures
+type failingClient struct {
+	client.Client
+}
+
+func (f *failingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return &errorForTest{msg: "simulated client failure"}
+}
+
+type errorForTest struct {
+	msg string
+}
+
+func (e *errorForTest) Error() string {
+	return e.msg
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // CRON_TZ must work in minimal images without zoneinfo
+)
+
+const (
+	// MaintenanceWindowAnnotation is the HCO annotation restricting when disruptive assets are applied
+	// Format: "[CRON_TZ=<zone>] <minute> <hour> <day-of-month> <month> <day-of-week> <duration>"
+	// Example: "CRON_TZ=Europe/Berlin 0 22 * * 1-5 6h" opens a 6h window at 22:00 on weekdays.
+	MaintenanceWindowAnnotation = "platform.kubevirt.io/maintenance-window"
+
+	// minMaintenanceWindowDuration makes sure the window spans a few 5-minute reconciliation passes
+	minMaintenanceWindowDuration = 15 * time.Minute
+
+	// maxMaintenanceWindowDuration bounds the window so that a typo can't open it for good
+	maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+	// maxScheduleSearch bounds the search for the next window start (covers "29 Feb")
+	maxScheduleSearch = 5 * 366 * 24 * time.Hour
+)
+
+// MaintenanceWindow is a recurring time window in which disruptive assets may be applied
+type MaintenanceWindow struct {
+	schedule *cronSchedule
+	location *time.Location
+	Duration time.Duration
+}
+
+// ParseMaintenanceWindow parses the maintenance-window annotation
+// Returns nil (no restriction) for an empty annotation.
+func ParseMaintenanceWindow(annotation string) (*MaintenanceWindow, error) {
+	fields := strings.Fields(annotation)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	location := time.UTC
+	if zone, ok := strings.CutPrefix(fields[0], "CRON_TZ="); ok {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", zone, err)
+		}
+		location = loc
+		fields = fields[1:]
+	}
+
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("expected 5 schedule fields and a duration, got %d fields", len(fields))
+	}
+
+	schedule, err := parseCronSchedule(fields[:5])
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := time.ParseDuration(fields[5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q: %w", fields[5], err)
+	}
+	if duration < minMaintenanceWindowDuration || duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("duration must be between %s and %s, got %s",
+			minMaintenanceWindowDuration, maxMaintenanceWindowDuration, duration)
+	}
+
+	return &MaintenanceWindow{schedule: schedule, location: location, Duration: duration}, nil
+}
+
+// IsOpen returns true if now falls into a window: a schedule start s with s <= now < s+Duration
+func (w *MaintenanceWindow) IsOpen(now time.Time) bool {
+	start, ok := w.nextStart(now.Add(-w.Duration))
+	return ok && !start.After(now)
+}
+
+// NextOpen returns the start of the next window after now
+// Returns false if the schedule never matches (e.g. "0 0 31 2 *").
+func (w *MaintenanceWindow) NextOpen(now time.Time) (time.Time, bool) {
+	return w.nextStart(now)
+}
+
+// nextStart returns the first schedule match strictly after t
+func (w *MaintenanceWindow) nextStart(t time.Time) (time.Time, bool) {
+	// Start at the next full minute; schedules have minute precision
+	t = t.In(w.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for t.Before(limit) {
+		switch {
+		case !w.schedule.month[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, w.location)
+		case !w.schedule.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, w.location)
+		case !w.schedule.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, w.location)
+		case !w.schedule.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cronSchedule is a parsed 5-field cron expression
+type cronSchedule struct {
+	minute     map[int]bool
+	hour       map[int]bool
+	dayOfMonth map[int]bool
+	month      map[time.Month]bool
+	dayOfWeek  map[int]bool
+
+	// Cron matches either day field if both are restricted, the other one if one is "*"
+	dayOfMonthAny bool
+	dayOfWeekAny  bool
+}
+
+// parseCronSchedule parses minute, hour, day-of-month, month and day-of-week fields
+// Each field supports "*", single values, ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10").
+func parseCronSchedule(fields []string) (*cronSchedule, error) {
+	bounds := []struct {
+		name     string
+		min, max int
+	}{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day-of-month", 1, 31},
+		{"month", 1, 12},
+		{"day-of-week", 0, 7},
+	}
+
+	values := make([]map[int]bool, len(bounds))
+	for i, b := range bounds {
+		set, err := parseCronField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %w", b.name, fields[i], err)
+		}
+		values[i] = set
+	}
+
+	// Sunday is both 0 and 7
+	if values[4][7] {
+		values[4][0] = true
+	}
+
+	months := make(map[time.Month]bool, len(values[3]))
+	for m := range values[3] {
+		months[time.Month(m)] = true
+	}
+
+	return &cronSchedule{
+		minute:        values[0],
+		hour:          values[1],
+		dayOfMonth:    values[2],
+		month:         months,
+		dayOfWeek:     values[4],
+		dayOfMonthAny: fields[2] == "*",
+		dayOfWeekAny:  fields[4] == "*",
+	}, nil
+}
+
+// matchesDay applies the cron day-of-month/day-of-week rule
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dayOfMonth[t.Day()]
+	dowMatch := s.dayOfWeek[int(t.Weekday())]
+	switch {
+	case s.dayOfMonthAny && s.dayOfWeekAny:
+		return true
+	case s.dayOfMonthAny:
+		return dowMatch
+	case s.dayOfWeekAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// parseCronField parses a single cron field into the set of matching values
+func parseCronField(field string, minValue, maxValue int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		low, high := minValue, maxValue
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return nil, fmt.Errorf("invalid value %q", lowPart)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return nil, fmt.Errorf("invalid value %q", highPart)
+				}
+			} else if hasStep {
+				// "5/15" means from 5 to the end in steps of 15
+				high = maxValue
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return nil, fmt.Errorf("%s out of range %d-%d", part, minValue, maxValue)
+		}
+
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
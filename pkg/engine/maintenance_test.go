@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	window, err := ParseMaintenanceWindow("")
+	if err != nil || window != nil {
+		t.Errorf("empty annotation = %v, %v, want no window", window, err)
+	}
+
+	invalid := map[string]string{
+		"missing duration":  "0 22 * * 1-5",
+		"bad duration":      "0 22 * * 1-5 forever",
+		"too short":         "0 22 * * 1-5 5m",
+		"too long":          "0 22 * * 1-5 200h",
+		"minute range":      "60 22 * * * 1h",
+		"inverted range":    "0 22-20 * * * 1h",
+		"zero step":         "*/0 22 * * * 1h",
+		"unknown time zone": "CRON_TZ=Mars/Olympus 0 22 * * * 1h",
+	}
+	for name, annotation := range invalid {
+		if _, err := ParseMaintenanceWindow(annotation); err == nil {
+			t.Errorf("%s: ParseMaintenanceWindow(%q) should fail", name, annotation)
+		}
+	}
+}
+
+func TestMaintenanceWindowIsOpen(t *testing.T) {
+	// Weekdays from 22:00 to 04:00 the next morning
+	window, err := ParseMaintenanceWindow("0 22 * * 1-5 6h")
+	if err != nil {
+		t.Fatalf("ParseMaintenanceWindow() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		now  string
+		want bool
+	}{
+		{"Monday business hours", "2026-10-12T10:00:00Z", false},
+		{"Monday window start", "2026-10-12T22:00:00Z", true},
+		{"Tuesday early morning", "2026-10-13T03:59:00Z", true},
+		{"Tuesday window end", "2026-10-13T04:00:00Z", false},
+		{"Saturday night, Friday window over", "2026-10-17T22:30:00Z", false},
+		{"Saturday early morning, Friday window", "2026-10-17T01:00:00Z", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.now)
+			if got := window.IsOpen(now); got != tt.want {
+				t.Errorf("IsOpen(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+
+	now, _ := time.Parse(time.RFC3339, "2026-10-17T10:00:00Z") // Saturday
+	next, ok := window.NextOpen(now)
+	if want := "2026-10-19T22:00:00Z"; !ok || next.UTC().Format(time.RFC3339) != want {
+		t.Errorf("NextOpen() = %s, want %s", next, want)
+	}
+}
+
+func TestMaintenanceWindowTimeZone(t *testing.T) {
+	window, err := ParseMaintenanceWindow("CRON_TZ=Europe/Berlin 0 22 * * * 1h")
+	if err != nil {
+		t.Fatalf("ParseMaintenanceWindow() error = %v", err)
+	}
+
+	// 22:30 in Berlin (CEST, UTC+2) is 20:30 UTC
+	now, _ := time.Parse(time.RFC3339, "2026-07-01T20:30:00Z")
+	if !window.IsOpen(now) {
+		t.Error("window should be open at 22:30 Berlin time")
+	}
+	if window.IsOpen(now.Add(2 * time.Hour)) {
+		t.Error("window should be closed at 00:30 Berlin time")
+	}
+}
+
+func TestCronDayMatching(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		now        string
+		want       bool
+	}{
+		// Both day fields restricted: either one matches
+		{"day of month matches", "0 0 1 * 1 1h", "2026-10-01T00:30:00Z", true},        // Thursday the 1st
+		{"day of week matches", "0 0 1 * 1 1h", "2026-10-12T00:30:00Z", true},         // Monday the 12th
+		{"neither day matches", "0 0 1 * 1 1h", "2026-10-13T00:30:00Z", false},        // Tuesday the 13th
+		{"sunday as 7", "0 0 * * 7 1h", "2026-10-18T00:30:00Z", true},                 // Sunday
+		{"steps", "*/15 * * * * 15m", "2026-10-13T10:44:00Z", true},                   // always open
+		{"month restriction", "0 0 * 1,7 * 24h", "2026-10-13T10:00:00Z", false},       // October
+		{"month range with step", "0 0 * 1-12/3 * 24h", "2026-10-01T10:00:00Z", true}, // Jan, Apr, Jul, Oct
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := ParseMaintenanceWindow(tt.annotation)
+			if err != nil {
+				t.Fatalf("ParseMaintenanceWindow(%q) error = %v", tt.annotation, err)
+			}
+			now, _ := time.Parse(time.RFC3339, tt.now)
+			if got := window.IsOpen(now); got != tt.want {
+				t.Errorf("IsOpen(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
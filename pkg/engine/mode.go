@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+)
+
+// Mode controls whether the reconciler writes to the cluster
+type Mode string
+
+const (
+	// ModeEnforce applies the effective desired state (default)
+	ModeEnforce Mode = "enforce"
+
+	// ModeAudit runs the full Patched Baseline pipeline but never writes,
+	// reporting drift through metrics, events and logs instead
+	ModeAudit Mode = "audit"
+)
+
+// ParseMode validates a mode name
+func ParseMode(value string) (Mode, error) {
+	switch Mode(value) {
+	case ModeEnforce, ModeAudit:
+		return Mode(value), nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: must be %q or %q", value, ModeEnforce, ModeAudit)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// InvalidOverridesError is returned when the overrides ConfigMap referenced by the HCO is missing or malformed
+// Reconciliation goes on without overrides then, unlike when the ConfigMap can't be read.
+type InvalidOverridesError struct {
+	ConfigMap string
+	Err       error
+}
+
+func (e *InvalidOverridesError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidOverridesError) Unwrap() error {
+	return e.Err
+}
+
+// IsInvalidOverrides checks if an error is an InvalidOverridesError
+func IsInvalidOverrides(err error) bool {
+	_, ok := err.(*InvalidOverridesError)
+	return ok
+}
+
+// LoadAssetOverrides reads the overrides ConfigMap referenced by the HCO from namespace
+// Returns nil if the HCO doesn't reference one, and an InvalidOverridesError if it is missing
+// or malformed.
+func LoadAssetOverrides(ctx context.Context, c client.Reader, hco *unstructured.Unstructured, namespace string) (map[string]overrides.AssetOverrides, error) {
+	name := hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap]
+	if name == "" {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if errors.IsNotFound(err) {
+		return nil, &InvalidOverridesError{ConfigMap: name, Err: err}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overrides ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	assetOverrides, err := overrides.ParseOverridesConfigMap(configMap.Data)
+	if err != nil {
+		return nil, &InvalidOverridesError{ConfigMap: name, Err: err}
+	}
+	return assetOverrides, nil
+}
+
+// LoadPatchPolicy reads the patch policy ConfigMap of the policy namespace
+// Returns nil without a policy. The policy namespace is not cached by the manager, so c
+// should read from the API server.
+func LoadPatchPolicy(ctx context.Context, c client.Reader) (*overrides.PatchPolicy, error) {
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: overrides.PatchPolicyConfigMapName, Namespace: overrides.PatchPolicyNamespace}, configMap)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patch policy ConfigMap: %w", err)
+	}
+	return overrides.ParsePatchPolicy(configMap.Data)
+}
@@ -20,10 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -35,15 +39,44 @@ import (
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
+// maxEventFieldPaths caps the number of field paths listed in an event message
+const maxEventFieldPaths = 5
+
+// DefaultAssetConcurrency is the default number of assets of one dependency level reconciled in parallel
+const DefaultAssetConcurrency = 4
+
+// defaultParallelOutboundMigrationsPerNode is KubeVirt's default per-node outbound migration limit
+const defaultParallelOutboundMigrationsPerNode = 2
+
 // Patcher implements the Patched Baseline algorithm
 type Patcher struct {
 	renderer          *Renderer
+	loader            *assets.Loader
 	applier           *Applier
 	driftDetector     *DriftDetector
 	throttle          *throttling.TokenBucket
 	thrashingDetector *throttling.ThrashingDetector
 	client            client.Client
 	eventRecorder     *util.EventRecorder
+	mode              Mode
+	driftHistory      *DriftHistory
+	autoResume        throttling.AutoResumePolicy
+	checkpointer      *ThrottleCheckpointer
+	restored          bool
+	rolloutGate       *NodeRolloutGate
+	resultsMu         sync.Mutex
+	results           map[string]*assetResult             // Asset outcomes of the current pass, for depends_on
+	assetOverrides    map[string]overrides.AssetOverrides // Overrides ConfigMap entries of the current pass
+	initialOverrides  map[string]overrides.AssetOverrides // HCO initial-overrides of the current pass, by object key
+	patchPolicy       *overrides.PatchPolicy              // Paths patches may write to on sensitive kinds
+	concurrency       int
+}
+
+// assetResult is the outcome of an asset in the current reconciliation pass
+type assetResult struct {
+	err     error
+	skipped string                       // Reason the asset was skipped, empty if it was reconciled
+	objects []*unstructured.Unstructured // Objects rendered for the asset
 }
 
 // NewPatcher creates a new patcher
@@ -53,14 +86,107 @@ func NewPatcher(c client.Client, apiReader client.Reader, loader *assets.Loader)
 	renderer := NewRenderer(loader)
 	renderer.SetClient(c) // Enable CRD introspection and object queries in templates
 
+	// MachineConfigPools aren't labeled by us, so they must be read past the cache
+	var poolReader client.Reader = c
+	if apiReader != nil {
+		poolReader = apiReader
+	}
+
 	return &Patcher{
 		renderer:          renderer,
+		loader:            loader,
 		applier:           NewApplier(c, apiReader),
 		driftDetector:     NewDriftDetector(c),
 		throttle:          throttling.NewTokenBucket(),
 		thrashingDetector: throttling.NewThrashingDetector(),
 		client:            c,
+		mode:              ModeEnforce,
+		driftHistory:      NewDriftHistory(DefaultDriftHistorySize),
+		rolloutGate:       NewNodeRolloutGate(poolReader),
+		results:           make(map[string]*assetResult),
+		concurrency:       DefaultAssetConcurrency,
+	}
+}
+
+// SetConcurrency sets how many assets of one dependency level are reconciled in parallel
+// Values below 1 reconcile assets one at a time.
+func (p *Patcher) SetConcurrency(workers int) {
+	p.concurrency = max(workers, 1)
+}
+
+// BeginPass starts a reconciliation pass
+// It forgets the asset outcomes and MachineConfigPool states of the previous pass.
+// Call it before reconciling the HCO, so that other assets can depend on it.
+func (p *Patcher) BeginPass() {
+	p.resultsMu.Lock()
+	p.results = make(map[string]*assetResult)
+	p.resultsMu.Unlock()
+
+	p.rolloutGate.BeginPass()
+}
+
+// SetAssetOverrides sets the per-asset overrides of the HCO's overrides ConfigMap for this pass
+// They apply like override annotations on the managed objects; annotations take precedence.
+func (p *Patcher) SetAssetOverrides(assetOverrides map[string]overrides.AssetOverrides) {
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	p.assetOverrides = assetOverrides
+}
+
+// SetInitialOverrides sets the HCO's overrides for objects that don't exist yet for this pass
+// They are keyed by overrides.InitialOverridesKey and written to the objects on creation.
+func (p *Patcher) SetInitialOverrides(initialOverrides map[string]overrides.AssetOverrides) {
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	p.initialOverrides = initialOverrides
+}
+
+// SetPatchPolicy sets the policy allowing patches on paths of sensitive kinds
+// A nil policy blocks every patch on sensitive kinds.
+func (p *Patcher) SetPatchPolicy(policy *overrides.PatchPolicy) {
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	p.patchPolicy = policy
+}
+
+// currentPatchPolicy returns the patch policy of the current pass
+func (p *Patcher) currentPatchPolicy() *overrides.PatchPolicy {
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	return p.patchPolicy
+}
+
+// initialOverrideAnnotations returns the annotations an object gets from the HCO's initial overrides
+func (p *Patcher) initialOverrideAnnotations(obj *unstructured.Unstructured) map[string]string {
+	key := overrides.InitialOverridesKey(obj.GetKind(), obj.GetNamespace(), obj.GetName())
+
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	if entry, ok := p.initialOverrides[key]; ok {
+		return entry.Annotations()
+	}
+	return nil
+}
+
+// overrideSource returns the object whose override annotations drive steps 2-4
+// That is live itself, or a copy of live with the asset's ConfigMap overrides merged in.
+// Returns nil if the object doesn't exist yet and no overrides apply.
+func (p *Patcher) overrideSource(assetName string, live *unstructured.Unstructured, liveExists bool) *unstructured.Unstructured {
+	p.resultsMu.Lock()
+	configured, ok := p.assetOverrides[assetName]
+	p.resultsMu.Unlock()
+
+	if !ok {
+		// A missing object may carry the initial overrides it will be created with
+		if liveExists || len(live.GetAnnotations()) > 0 {
+			return live
+		}
+		return nil
 	}
+
+	source := live.DeepCopy()
+	source.SetAnnotations(overrides.MergeOverrides(configured, source.GetAnnotations()))
+	return source
 }
 
 // SetEventRecorder sets the event recorder for this patcher
@@ -68,11 +194,79 @@ func (p *Patcher) SetEventRecorder(recorder *util.EventRecorder) {
 	p.eventRecorder = recorder
 }
 
+// SetMode sets the reconciliation mode (enforce or audit)
+func (p *Patcher) SetMode(mode Mode) {
+	p.mode = mode
+}
+
+// SetAutoResumePolicy sets the policy for resuming resources paused due to an edit war
+// The zero policy (default) keeps every pause until the annotation is removed
+func (p *Patcher) SetAutoResumePolicy(policy throttling.AutoResumePolicy) {
+	p.autoResume = policy
+}
+
+// SetThrottleCheckpointer sets where the anti-thrashing state is persisted across restarts
+// Without a checkpointer the state is in-memory only
+func (p *Patcher) SetThrottleCheckpointer(checkpointer *ThrottleCheckpointer) {
+	p.checkpointer = checkpointer
+}
+
+// RestoreThrottleState loads the persisted anti-thrashing state, once per patcher
+// Failures are logged and retried on the next call, reconciliation continues with in-memory state.
+func (p *Patcher) RestoreThrottleState(ctx context.Context) {
+	if p.checkpointer == nil || p.restored {
+		return
+	}
+
+	checkpoint, err := p.checkpointer.Load(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to restore anti-thrashing state, starting fresh")
+		return
+	}
+	p.throttle.Restore(checkpoint.Buckets)
+	p.thrashingDetector.Restore(checkpoint.Thrashing)
+	p.restored = true
+
+	log.FromContext(ctx).Info("Restored anti-thrashing state",
+		"buckets", len(checkpoint.Buckets),
+		"thrashing", len(checkpoint.Thrashing),
+	)
+}
+
+// SaveThrottleState persists the anti-thrashing state
+// Nothing is written in audit mode or before the state was restored, so a checkpoint is never clobbered.
+func (p *Patcher) SaveThrottleState(ctx context.Context) {
+	if p.checkpointer == nil || !p.restored || p.mode == ModeAudit {
+		return
+	}
+
+	checkpoint := &ThrottleCheckpoint{
+		Buckets:   p.throttle.Snapshot(),
+		Thrashing: p.thrashingDetector.Snapshot(),
+	}
+	if err := p.checkpointer.Save(ctx, checkpoint); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to persist anti-thrashing state")
+	}
+}
+
+// DriftHistory returns the recent drift records of the objects managed by this patcher
+func (p *Patcher) DriftHistory() *DriftHistory {
+	return p.driftHistory
+}
+
 // ReconcileAsset performs the full Patched Baseline algorithm for an asset
-// Returns true if the asset was applied, false if skipped/unchanged
-//
-//nolint:gocognit // This function implements the 7-step Patched Baseline Algorithm which is inherently complex
+// Returns true if any object of the asset was applied, false if skipped/unchanged
+// In audit mode, true means an object drifted and would have been applied
+// The outcome is recorded for assets that depend on this one.
 func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) (bool, error) {
+	desiredObjs, applied, err := p.reconcileAsset(ctx, assetMeta, renderCtx)
+	p.recordResult(assetMeta.Name, &assetResult{err: err, objects: desiredObjs})
+	return applied, err
+}
+
+// reconcileAsset renders an asset and reconciles each of its objects
+// Returns the rendered objects along with the ReconcileAsset results.
+func (p *Patcher) reconcileAsset(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) ([]*unstructured.Unstructured, bool, error) {
 	logger := log.FromContext(ctx)
 
 	logger.V(1).Info("Reconciling asset",
@@ -82,19 +276,62 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 	)
 
 	// Step 1: Render asset template → Opinionated State
-	desired, err := p.renderer.RenderAsset(assetMeta, renderCtx)
+	// An asset may render multiple objects (e.g. one MachineConfig per CPU vendor)
+	desiredObjs, err := p.renderer.RenderMultiAsset(assetMeta, renderCtx)
 	if err != nil {
-		return false, fmt.Errorf("failed to render asset %s: %w", assetMeta.Name, err)
+		return nil, false, fmt.Errorf("failed to render asset %s: %w", assetMeta.Name, err)
 	}
 
 	// Handle conditional assets that don't apply (template rendered empty)
-	if desired == nil {
+	if len(desiredObjs) == 0 {
 		logger.V(1).Info("Asset not applicable (conditions not met)",
 			"name", assetMeta.Name,
 		)
-		return false, nil
+		return nil, false, p.pruneAsset(ctx, assetMeta, nil, renderCtx)
+	}
+
+	anyApplied := false
+	var errs []error
+	for _, desired := range desiredObjs {
+		if assetMeta.Prune {
+			setAssetLabel(desired, assetMeta.Name)
+		}
+		applied, err := p.reconcileObject(ctx, assetMeta, desired, renderCtx)
+		if err != nil {
+			// Keep going so one failing object doesn't block its siblings
+			errs = append(errs, err)
+			continue
+		}
+		if applied {
+			anyApplied = true
+		}
+	}
+
+	// Objects no longer rendered are only pruned once the rendered ones are reconciled
+	if len(errs) == 0 {
+		if err := p.pruneAsset(ctx, assetMeta, desiredObjs, renderCtx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 1 {
+		return desiredObjs, anyApplied, errs[0]
+	}
+	if len(errs) > 1 {
+		return desiredObjs, anyApplied, fmt.Errorf("failed to reconcile %d/%d objects of asset %s: %w",
+			len(errs), len(desiredObjs), assetMeta.Name, utilerrors.NewAggregate(errs))
 	}
 
+	return desiredObjs, anyApplied, nil
+}
+
+// reconcileObject runs steps 1.5-7 of the Patched Baseline algorithm for a single rendered object
+//
+//nolint:gocognit // This function implements the Patched Baseline Algorithm which is inherently complex
+func (p *Patcher) reconcileObject(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) (bool, error) {
+	logger := log.FromContext(ctx)
+	var err error
+
 	// Root Exclusion: Check if this resource is explicitly disabled via annotation
 	disabledAnnotation := renderCtx.HCO.GetAnnotations()[DisabledResourcesAnnotation]
 	if disabledAnnotation != "" {
@@ -168,19 +405,64 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 
 	// Step 1.5: Check if reconciliation is paused due to edit war
 	if liveExists && overrides.IsPaused(live) {
-		logger.Info("Reconciliation paused due to edit war detection",
+		resumeAt, autoResume := overrides.GetResumeAt(live)
+		// Audit mode never writes, so an expired pause stays until the controller enforces again
+		if !autoResume || time.Now().Before(resumeAt) || p.mode == ModeAudit {
+			logger.Info("Reconciliation paused due to edit war detection",
+				"name", assetMeta.Name,
+				"kind", desired.GetKind(),
+				"namespace", desired.GetNamespace(),
+				"objectName", desired.GetName(),
+				"autoResume", autoResume,
+			)
+			// Don't emit events repeatedly - annotation is self-documenting
+			// User must remove annotation to resume reconciliation, unless an auto-resume is scheduled
+			pause := observability.PausePermanent
+			if autoResume {
+				pause = observability.PauseAutoResume
+			}
+			observability.SetPaused(desired, true, pause)
+			return false, nil
+		}
+
+		// Cool-down elapsed: resume with a fresh update budget
+		live, err = p.resumePaused(ctx, live)
+		if err != nil {
+			return false, fmt.Errorf("failed to resume paused object: %w", err)
+		}
+		resourceKey := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+		p.throttle.Reset(resourceKey)
+		p.thrashingDetector.Reset(resourceKey)
+		observability.SetPaused(desired, false, "")
+
+		episode, _ := overrides.GetPauseEpisode(live)
+		logger.Info("Cool-down elapsed, resuming reconciliation",
 			"name", assetMeta.Name,
-			"kind", desired.GetKind(),
-			"namespace", desired.GetNamespace(),
-			"objectName", desired.GetName(),
+			"key", resourceKey,
+			"episode", episode,
 		)
-		// Don't emit metrics or events repeatedly - annotation is self-documenting
-		// User must remove annotation to resume reconciliation
-		return false, nil
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.ReconcileResumed(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), episode)
+		}
+	}
+
+	// A missing object is created with the HCO's initial overrides as annotations,
+	// from here on they apply as if the user had annotated the object
+	if !liveExists {
+		if initial := p.initialOverrideAnnotations(desired); len(initial) > 0 {
+			logger.V(1).Info("Applying initial overrides to new object",
+				"name", assetMeta.Name,
+				"kind", desired.GetKind(),
+			)
+			live.SetAnnotations(initial)
+		}
 	}
 
+	// Overrides come from annotations on live and from the overrides ConfigMap
+	overrideSource := p.overrideSource(assetMeta.Name, live, liveExists)
+
 	// Step 2: Check opt-out annotation (mode: unmanaged)
-	if liveExists && overrides.IsUnmanaged(live) {
+	if overrideSource != nil && overrides.IsUnmanaged(overrideSource) {
 		logger.V(1).Info("Asset is unmanaged, skipping",
 			"name", assetMeta.Name,
 			"kind", desired.GetKind(),
@@ -198,90 +480,107 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 	}
 
 	// Step 3: Apply user patch (in-memory) → Modified State
-	// Copy patch annotation from live to desired, then apply it
-	if liveExists {
-		liveAnnotations := live.GetAnnotations()
-		if patchStr, exists := liveAnnotations[overrides.PatchAnnotation]; exists && patchStr != "" {
-			// Track patch customization
-			observability.SetCustomization(desired, "patch")
-
-			// Copy patch annotation to desired temporarily
+	// Copy patch annotations from live to desired, then apply them
+	if overrideSource != nil && copyPatchAnnotations(overrideSource, desired) {
+		// Track patch customization
+		observability.SetCustomization(desired, "patch")
+
+		// Validate patch security before applying
+		if err := overrides.ValidateAnnotations(desired, p.currentPatchPolicy()); err != nil {
+			logger.Error(err, "Patch validation failed, using desired without patch",
+				"name", assetMeta.Name,
+				"kind", desired.GetKind(),
+			)
+			// Record event about invalid/insecure patch
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
+			}
+			// Remove invalid patch annotations and continue with unpatched desired
 			desiredAnnotations := desired.GetAnnotations()
-			if desiredAnnotations == nil {
-				desiredAnnotations = make(map[string]string)
+			for _, annotation := range overrides.PatchAnnotations {
+				delete(desiredAnnotations, annotation)
 			}
-			desiredAnnotations[overrides.PatchAnnotation] = patchStr
 			desired.SetAnnotations(desiredAnnotations)
+		} else {
+			p.applyUserPatch(ctx, assetMeta, desired, renderCtx)
+		}
 
-			// Validate patch security before applying
-			if err := overrides.ValidateAnnotations(desired); err != nil {
-				logger.Error(err, "Patch validation failed, using desired without patch",
-					"name", assetMeta.Name,
-					"kind", desired.GetKind(),
-				)
-				// Record event about invalid/insecure patch
-				if p.eventRecorder != nil && renderCtx.HCO != nil {
-					p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
-				}
-				// Remove invalid patch annotation and continue with unpatched desired
-				delete(desiredAnnotations, overrides.PatchAnnotation)
-				desired.SetAnnotations(desiredAnnotations)
-			} else {
-				// Apply the patch (modifies desired in-place)
-				patched, err := overrides.ApplyJSONPatch(desired)
-				if err != nil {
-					logger.Error(err, "Failed to apply JSON patch, using desired without patch",
-						"name", assetMeta.Name,
-					)
-					// Record event about invalid patch
-					if p.eventRecorder != nil && renderCtx.HCO != nil {
-						p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
-					}
-					// Continue with unpatched desired (don't fail reconciliation)
-				} else if patched && p.eventRecorder != nil && renderCtx.HCO != nil {
-					// Record successful patch application
-					// Count operations in the patch string
-					operations := countJSONPatchOperations(patchStr)
-					p.eventRecorder.PatchApplied(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), operations)
-				}
+		// Patches from the overrides ConfigMap are not written to the object
+		desiredAnnotations := desired.GetAnnotations()
+		for _, annotation := range overrides.PatchAnnotations {
+			if _, onLive := live.GetAnnotations()[annotation]; !onLive {
+				delete(desiredAnnotations, annotation)
 			}
 		}
+		desired.SetAnnotations(desiredAnnotations)
 	}
 
 	// Step 4: Mask ignored fields → Effective Desired State
 	if liveExists {
 		// Check if ignore-fields annotation exists
-		liveAnnotations := live.GetAnnotations()
-		if _, exists := liveAnnotations[overrides.AnnotationIgnoreFields]; exists {
+		// overrideSource is live, with ConfigMap overrides merged into its annotations
+		if _, exists := overrideSource.GetAnnotations()[overrides.AnnotationIgnoreFields]; exists {
 			// Track ignore-fields customization
 			observability.SetCustomization(desired, "ignore")
 		}
 
-		desired, err = overrides.MaskIgnoredFields(desired, live)
+		desired, err = overrides.MaskIgnoredFields(desired, overrideSource)
 		if err != nil {
 			return false, fmt.Errorf("failed to mask ignored fields: %w", err)
 		}
 	}
 
+	// Like patch annotations, carry the object's ignore-fields annotation in desired, so an
+	// annotation set from the initial overrides isn't dropped by the next apply
+	if ignoreFields, exists := live.GetAnnotations()[overrides.AnnotationIgnoreFields]; exists {
+		desiredAnnotations := desired.GetAnnotations()
+		if desiredAnnotations == nil {
+			desiredAnnotations = make(map[string]string)
+		}
+		desiredAnnotations[overrides.AnnotationIgnoreFields] = ignoreFields
+		desired.SetAnnotations(desiredAnnotations)
+	}
+
 	// Step 5: Drift detection
 	// Ensure the managed-by label is present on desired before comparison,
 	// mirroring what Applier.Apply() does before the actual SSA apply.
 	// Without this the label would always appear as a spurious diff.
 	ensureManagedByLabel(desired)
 
-	hasDrift := false
-	if liveExists {
-		hasDrift, err = p.driftDetector.DetectDrift(ctx, desired, live)
-		if err != nil {
-			// Fall back to simple check if SSA dry-run fails
-			logger.V(1).Info("SSA dry-run failed, using simple drift check",
-				"error", err.Error(),
-			)
-			hasDrift = p.driftDetector.SimpleDriftCheck(desired, live)
-		}
-	} else {
+	if !liveExists {
 		// Object doesn't exist - needs creation
-		hasDrift = true
+		live = nil
+	}
+	hasDrift, diff, err := p.driftDetector.DetectDrift(ctx, desired, live)
+	if err != nil {
+		// Fall back to simple check if SSA dry-run fails
+		logger.V(1).Info("SSA dry-run failed, using simple drift check",
+			"error", err.Error(),
+		)
+		hasDrift = p.driftDetector.SimpleDriftCheck(desired, live)
+		if hasDrift {
+			diff = p.driftDetector.Diff(ctx, desired, live)
+		}
+	}
+
+	// Keep the drifted fields for the debug server
+	var driftRecord DriftRecord
+	if hasDrift {
+		driftRecord = newDriftRecord(assetMeta.Name, desired, live, diff)
+		p.driftHistory.Record(driftRecord)
+	}
+
+	// Pools of node-level objects are observed on every pass, pending change or not,
+	// so the degraded pool metric clears once a pool recovers
+	if IsNodeLevelKind(desired.GetKind()) {
+		if _, err := p.observeNodePools(ctx, desired, renderCtx); err != nil {
+			logger.Error(err, "Failed to read MachineConfigPool", "name", assetMeta.Name)
+		}
+	}
+
+	// Audit mode stops here: report drift, never throttle, pause or apply
+	if p.mode == ModeAudit {
+		return p.reportAuditDrift(ctx, assetMeta, desired, liveExists, hasDrift, diff, renderCtx), nil
 	}
 
 	if !hasDrift {
@@ -300,6 +599,31 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		p.eventRecorder.DriftDetected(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName())
 	}
 
+	// Step 5.5: Disruption gates - disruptive assets wait for the maintenance window,
+	// MachineConfig/KubeletConfig changes wait for their pool
+	if assetMeta.Disruptive && !p.inMaintenanceWindow(ctx, desired, renderCtx) {
+		observability.SetCompliance(desired, 0)
+		return false, nil
+	}
+	if IsNodeLevelKind(desired.GetKind()) {
+		ready, err := p.checkNodeRollout(ctx, desired, renderCtx)
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			observability.SetCompliance(desired, 0)
+			return false, nil
+		}
+		ready, err = p.checkNodeMaintenance(ctx, desired, renderCtx)
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			observability.SetCompliance(desired, 0)
+			return false, nil
+		}
+	}
+
 	// Step 6: Anti-thrashing gate (two-level protection)
 	resourceKey := throttling.MakeResourceKey(
 		desired.GetNamespace(),
@@ -307,32 +631,48 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		desired.GetKind(),
 	)
 
-	if err := p.throttle.Record(resourceKey); err != nil {
+	// Assets may tighten or relax the limits (e.g. reboot-inducing MachineConfigs)
+	capacity, window, threshold := assetMeta.ThrottlingLimits(desired.GetKind())
+
+	if err := p.throttle.RecordWithSettings(resourceKey, capacity, window); err != nil {
 		if throttling.IsThrottled(err) {
 			// Token bucket exhausted - check thrashing detector
-			shouldPause := p.thrashingDetector.RecordThrottle(resourceKey)
+			shouldPause := p.recordThrottle(assetMeta, desired.GetKind(), resourceKey, threshold)
 
 			if shouldPause {
-				// Edit war detected - pause reconciliation
+				// Edit war detected - name the other writers of our fields and pause reconciliation
+				competitors := driftRecord.CompetingManagers()
+				competitorSummary := summarizeCompetitors(competitors)
+				primaryCompetitor := ""
+				if len(competitors) > 0 {
+					primaryCompetitor = competitors[0].Manager
+				}
+
 				logger.Info("Edit war detected, pausing reconciliation",
 					"name", assetMeta.Name,
 					"key", resourceKey,
 					"attempts", p.thrashingDetector.GetAttempts(resourceKey),
+					"competitors", competitorSummary,
 				)
 
 				// Emit metric only once when threshold is reached
 				if p.thrashingDetector.ShouldEmitMetric(resourceKey) {
-					observability.IncThrashing(desired)
+					observability.IncThrashing(desired, primaryCompetitor)
 				}
 
 				// Set pause annotation on live object
+				var resumeAfter time.Duration
 				if liveExists {
-					if err := p.setPauseAnnotation(ctx, live); err != nil {
+					if resumeAfter, err = p.setPauseAnnotation(ctx, live, competitorSummary); err != nil {
 						logger.Error(err, "Failed to set pause annotation", "key", resourceKey)
 						// Continue anyway - operator will retry
 					} else {
 						// Record that this resource is now paused
-						observability.SetPaused(desired, true)
+						pause := observability.PausePermanent
+						if resumeAfter > 0 {
+							pause = observability.PauseAutoResume
+						}
+						observability.SetPaused(desired, true, pause)
 					}
 				}
 
@@ -344,10 +684,12 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 						desired.GetNamespace(),
 						desired.GetName(),
 						p.thrashingDetector.GetAttempts(resourceKey),
+						competitorSummary,
+						resumeAfter,
 					)
 				}
 
-				return false, fmt.Errorf("reconciliation paused due to edit war (threshold: %d throttles)", throttling.ThrashingThreshold)
+				return false, fmt.Errorf("reconciliation paused due to edit war (threshold: %d throttles)", threshold)
 			}
 
 			// First or second throttle - log and continue
@@ -395,7 +737,7 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		p.thrashingDetector.RecordSuccess(resourceKey)
 
 		// Clear paused state - resource is now active
-		observability.SetPaused(desired, false)
+		observability.SetPaused(desired, false, "")
 
 		// Record successful asset application
 		if p.eventRecorder != nil && renderCtx.HCO != nil {
@@ -413,47 +755,61 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 	return applied, nil
 }
 
-// ReconcileAssets reconciles multiple assets in order
+// ReconcileAssets reconciles multiple assets, dependencies first
+// Assets are expected in dependency order (see Registry.ListAssetsInDependencyOrder).
+// Consecutive assets of the same dependency level are reconciled concurrently, up to
+// the patcher concurrency; a level starts once the previous one is done.
 func (p *Patcher) ReconcileAssets(ctx context.Context, assetMetas []assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) (int, error) {
 	// Opportunistically clean up stale throttle bucket entries (prevents memory leak)
 	// This runs once per reconciliation loop to remove entries for deleted resources
 	p.throttle.CleanupStale(throttling.DefaultTTL)
 
-	appliedCount := 0
-	var failedAssets []string
-	var errors []error
+	// Each worker writes only its own slot, so the outcome order is the asset order
+	type outcome struct {
+		applied bool
+		err     error
+	}
+	outcomes := make([]outcome, len(assetMetas))
 
-	for i := range assetMetas {
-		applied, err := p.ReconcileAsset(ctx, &assetMetas[i], renderCtx)
-		if err != nil {
-			// Collect error and failed asset name
-			errors = append(errors, err)
-			failedAssets = append(failedAssets, assetMetas[i].Name)
-
-			// Continue with other assets even if one fails
-			log.FromContext(ctx).Error(err, "Failed to reconcile asset, continuing with others",
-				"asset", assetMetas[i].Name,
-				"failedSoFar", len(failedAssets),
-			)
-			continue
+	for start := 0; start < len(assetMetas); {
+		end := start + 1
+		for end < len(assetMetas) && assetMetas[end].DependencyLevel == assetMetas[start].DependencyLevel {
+			end++
 		}
 
-		if applied {
+		var wg sync.WaitGroup
+		workers := make(chan struct{}, p.concurrency)
+		for i := start; i < end; i++ {
+			workers <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-workers }()
+				applied, err := p.reconcileWithDependencies(ctx, &assetMetas[i], renderCtx)
+				outcomes[i] = outcome{applied: applied, err: err}
+			})
+		}
+		wg.Wait()
+
+		start = end
+	}
+
+	appliedCount := 0
+	var errMsgs []string
+	for i, o := range outcomes {
+		if o.err != nil {
+			// Build detailed error message with all failures
+			errMsgs = append(errMsgs, fmt.Sprintf("[%s: %v]", assetMetas[i].Name, o.err))
+			continue
+		}
+		if o.applied {
 			appliedCount++
 		}
 	}
 
 	// Return aggregated error if any assets failed
 	// This ensures reconciliation fails and retries, but only after attempting all assets
-	if len(errors) > 0 {
-		// Build detailed error message with all failures
-		var errMsgs []string
-		for i, err := range errors {
-			errMsgs = append(errMsgs, fmt.Sprintf("[%s: %v]", failedAssets[i], err))
-		}
-
+	if len(errMsgs) > 0 {
 		return appliedCount, fmt.Errorf("failed to reconcile %d/%d assets: %s",
-			len(failedAssets),
+			len(errMsgs),
 			len(assetMetas),
 			strings.Join(errMsgs, "; "),
 		)
@@ -462,9 +818,351 @@ func (p *Patcher) ReconcileAssets(ctx context.Context, assetMetas []assets.Asset
 	return appliedCount, nil
 }
 
+// reconcileWithDependencies reconciles an asset unless one of its dependencies is not met
+func (p *Patcher) reconcileWithDependencies(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if reason := p.unmetDependency(ctx, assetMeta); reason != "" {
+		// Not an error: the failed dependency reports its own error, readiness is retried next pass
+		p.recordResult(assetMeta.Name, &assetResult{skipped: reason})
+		logger.Info("Skipping asset, dependency not met",
+			"asset", assetMeta.Name,
+			"reason", reason,
+		)
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.AssetSkipped(renderCtx.HCO, assetMeta.Name, reason)
+		}
+		return false, nil
+	}
+
+	applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx)
+	if err != nil {
+		// Continue with other assets even if one fails
+		logger.Error(err, "Failed to reconcile asset, continuing with others",
+			"asset", assetMeta.Name,
+		)
+	}
+	return applied, err
+}
+
+// copyPatchAnnotations copies the user's patch annotations from live to desired
+// Returns true if live has any patch annotation
+func copyPatchAnnotations(live, desired *unstructured.Unstructured) bool {
+	liveAnnotations := live.GetAnnotations()
+	desiredAnnotations := desired.GetAnnotations()
+	found := false
+	for _, annotation := range overrides.PatchAnnotations {
+		patchStr, exists := liveAnnotations[annotation]
+		if !exists || patchStr == "" {
+			continue
+		}
+		if desiredAnnotations == nil {
+			desiredAnnotations = make(map[string]string)
+		}
+		desiredAnnotations[annotation] = patchStr
+		found = true
+	}
+	if found {
+		desired.SetAnnotations(desiredAnnotations)
+	}
+	return found
+}
+
+// ApplyUserPatch applies the patch annotation of desired in-place, whatever its format
+// Strategic merge patches take their list merge keys from the CRD schemas of loader.
+// Returns the patch annotation found, "" if there is none, and whether it was applied.
+func ApplyUserPatch(loader *assets.Loader, desired *unstructured.Unstructured) (string, bool, error) {
+	annotations := desired.GetAnnotations()
+	switch {
+	case annotations[overrides.PatchAnnotation] != "":
+		patched, err := overrides.ApplyJSONPatch(desired)
+		return overrides.PatchAnnotation, patched, err
+	case annotations[overrides.MergePatchAnnotation] != "":
+		patched, err := overrides.ApplyMergePatch(desired)
+		return overrides.MergePatchAnnotation, patched, err
+	case annotations[overrides.StrategicMergePatchAnnotation] != "":
+		schema, err := loader.LoadCRDSchema(desired.GroupVersionKind())
+		if err != nil {
+			return overrides.StrategicMergePatchAnnotation, false, err
+		}
+		patched, err := overrides.ApplyStrategicMergePatch(desired, overrides.NewSchemaPatchMeta(desired.GetKind(), schema))
+		return overrides.StrategicMergePatchAnnotation, patched, err
+	}
+	return "", false, nil
+}
+
+// applyUserPatch applies the (validated) patch annotation of desired in-place
+// A patch that fails to apply is reported and skipped, desired stays unpatched.
+func (p *Patcher) applyUserPatch(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) {
+	logger := log.FromContext(ctx)
+	annotations := desired.GetAnnotations()
+
+	annotation, patched, err := ApplyUserPatch(p.loader, desired)
+	if annotation == "" {
+		return
+	}
+	if err != nil {
+		logger.Error(err, "Failed to apply patch, using desired without patch",
+			"name", assetMeta.Name,
+			"format", overrides.PatchFormat(annotation),
+		)
+		// Record event about invalid patch
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
+		}
+		// Continue with unpatched desired (don't fail reconciliation)
+		return
+	}
+	if !patched || p.eventRecorder == nil || renderCtx.HCO == nil {
+		return
+	}
+
+	// Record successful patch application
+	if annotation == overrides.PatchAnnotation {
+		// Count operations in the patch string
+		operations := countJSONPatchOperations(annotations[annotation])
+		p.eventRecorder.PatchApplied(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), operations)
+		return
+	}
+	p.eventRecorder.MergePatchApplied(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), overrides.PatchFormat(annotation))
+}
+
+// inMaintenanceWindow checks whether a disruptive object may be changed now
+// Without a maintenance-window annotation on the HCO, changes are always allowed.
+// An invalid annotation holds changes back: it expresses a restriction we can't honor.
+func (p *Patcher) inMaintenanceWindow(ctx context.Context, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) bool {
+	logger := log.FromContext(ctx)
+
+	annotation := renderCtx.HCO.GetAnnotations()[MaintenanceWindowAnnotation]
+	window, err := ParseMaintenanceWindow(annotation)
+	if err != nil {
+		logger.Error(err, "Invalid maintenance-window annotation, holding back disruptive change",
+			"annotation", annotation,
+			"kind", desired.GetKind(),
+			"objectName", desired.GetName(),
+		)
+		if p.eventRecorder != nil {
+			p.eventRecorder.InvalidMaintenanceWindow(renderCtx.HCO, err.Error())
+		}
+		return false
+	}
+
+	now := time.Now()
+	if window == nil || window.IsOpen(now) {
+		return true
+	}
+
+	nextWindow := "never"
+	if next, ok := window.NextOpen(now); ok {
+		nextWindow = next.Format(time.RFC3339)
+	}
+	logger.Info("Outside maintenance window, deferring disruptive change",
+		"kind", desired.GetKind(),
+		"namespace", desired.GetNamespace(),
+		"objectName", desired.GetName(),
+		"nextWindow", nextWindow,
+	)
+	if p.eventRecorder != nil {
+		p.eventRecorder.OutsideMaintenanceWindow(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), nextWindow)
+	}
+	return false
+}
+
+// observeNodePools reads the MachineConfigPools of a node-level object
+// Every pool is reported once per pass with the degraded pool metric, and with an event
+// when degraded, so the metric also clears for pools without a pending change.
+func (p *Patcher) observeNodePools(ctx context.Context, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) (PoolStatus, error) {
+	pool, firstSeen, err := p.rolloutGate.Check(ctx, desired)
+	for _, seen := range firstSeen {
+		if seen.State == PoolAbsent {
+			continue
+		}
+		observability.SetMachineConfigPoolDegraded(seen.Name, seen.State == PoolDegraded)
+		if seen.State == PoolDegraded && p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.MachineConfigPoolDegraded(renderCtx.HCO, seen.Name, seen.Message)
+		}
+	}
+	return pool, err
+}
+
+// checkNodeRollout checks whether the MachineConfigPool of a node-level object allows a change now
+func (p *Patcher) checkNodeRollout(ctx context.Context, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	pool, err := p.observeNodePools(ctx, desired, renderCtx)
+	if err != nil {
+		return false, err
+	}
+	if pool.Ready() {
+		return true, nil
+	}
+
+	logger.Info("Deferring node-level change until the MachineConfigPool is updated",
+		"kind", desired.GetKind(),
+		"objectName", desired.GetName(),
+		"pool", pool.Name,
+		"poolState", pool.State,
+		"message", pool.Message,
+	)
+	if pool.State == PoolUpdating && p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.RolloutDeferred(renderCtx.HCO, desired.GetKind(), desired.GetName(), pool.Name)
+	}
+	return false, nil
+}
+
+// checkNodeMaintenance checks whether a node-level change may drain one more node now
+// The Machine Config Operator drains a node to roll the change out, on top of the drains
+// of the Node Maintenance Operator. Every drained node live migrates its VMs away, so the
+// change waits while the drains in progress already reach the HCO's per-node outbound
+// migration limit.
+func (p *Patcher) checkNodeMaintenance(ctx context.Context, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	drains, err := p.rolloutGate.ActiveDrains(ctx)
+	if err != nil {
+		return false, err
+	}
+	limit := maxConcurrentDrains(renderCtx.HCO)
+	if drains < limit {
+		return true, nil
+	}
+
+	logger.Info("Deferring node-level change while nodes are drained for maintenance",
+		"kind", desired.GetKind(),
+		"objectName", desired.GetName(),
+		"drains", drains,
+		"limit", limit,
+	)
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.NodeMaintenanceDeferred(renderCtx.HCO, desired.GetKind(), desired.GetName(), drains, limit)
+	}
+	return false, nil
+}
+
+// maxConcurrentDrains returns how many nodes may be drained at once, the HCO's
+// parallelOutboundMigrationsPerNode or KubeVirt's default of 2
+func maxConcurrentDrains(hco *unstructured.Unstructured) int {
+	if hco == nil {
+		return defaultParallelOutboundMigrationsPerNode
+	}
+	limit, found, err := unstructured.NestedInt64(hco.Object, "spec", "liveMigrationConfig", "parallelOutboundMigrationsPerNode")
+	if !found || err != nil || limit < 1 {
+		return defaultParallelOutboundMigrationsPerNode
+	}
+	return int(limit)
+}
+
+// recordResult records the outcome of an asset in the current pass
+func (p *Patcher) recordResult(assetName string, result *assetResult) {
+	p.resultsMu.Lock()
+	defer p.resultsMu.Unlock()
+	p.results[assetName] = result
+}
+
+// unmetDependency returns why the dependencies of an asset are not met, empty if they are
+// A dependency is met if it was reconciled without error in this pass and, if the
+// dependency names a condition, every object it rendered reports that condition as True.
+// A dependency that doesn't apply to the cluster (conditions or CRD missing) only orders
+// the assets, so it is met unless a condition is required from it.
+func (p *Patcher) unmetDependency(ctx context.Context, assetMeta *assets.AssetMetadata) string {
+	for _, dep := range assetMeta.DependsOn {
+		p.resultsMu.Lock()
+		result := p.results[dep.Asset]
+		p.resultsMu.Unlock()
+
+		switch {
+		case result == nil && dep.Condition == "":
+			continue
+		case result == nil:
+			return fmt.Sprintf("dependency %s is not applicable", dep.Asset)
+		case result.err != nil:
+			return fmt.Sprintf("dependency %s failed", dep.Asset)
+		case result.skipped != "":
+			return fmt.Sprintf("dependency %s was skipped (%s)", dep.Asset, result.skipped)
+		}
+
+		if dep.Condition == "" {
+			continue
+		}
+		if len(result.objects) == 0 {
+			return fmt.Sprintf("dependency %s rendered no objects to become %s", dep.Asset, dep.Condition)
+		}
+		for _, obj := range result.objects {
+			ready, err := p.hasCondition(ctx, obj, dep.Condition)
+			if err != nil {
+				return fmt.Sprintf("failed to check dependency %s: %v", dep.Asset, err)
+			}
+			if !ready {
+				return fmt.Sprintf("waiting for %s %s of dependency %s to become %s",
+					obj.GetKind(), obj.GetName(), dep.Asset, dep.Condition)
+			}
+		}
+	}
+	return ""
+}
+
+// hasCondition checks whether the live copy of obj reports conditionType with status True
+func (p *Patcher) hasCondition(ctx context.Context, obj *unstructured.Unstructured, conditionType string) (bool, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if err := p.applier.GetDirect(ctx, key, live); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition["status"] == "True", nil
+		}
+	}
+	return false, nil
+}
+
+// reportAuditDrift records the drift of an object in audit mode without writing to the cluster
+// Returns true if the object drifted
+func (p *Patcher) reportAuditDrift(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, liveExists, hasDrift bool, diff map[string]interface{}, renderCtx *pkgcontext.RenderContext) bool {
+	if !hasDrift {
+		observability.SetCompliance(desired, 1)
+		return false
+	}
+	observability.SetCompliance(desired, 0)
+
+	action := "update"
+	if !liveExists {
+		action = "create"
+	}
+	paths := DiffFieldPaths(diff)
+
+	log.FromContext(ctx).Info("Audit mode: drift detected, not applying",
+		"name", assetMeta.Name,
+		"kind", desired.GetKind(),
+		"namespace", desired.GetNamespace(),
+		"objectName", desired.GetName(),
+		"action", action,
+		"diff", diff,
+	)
+
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.AuditDrift(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(),
+			action, summarizePaths(paths, maxEventFieldPaths))
+	}
+	return true
+}
+
 // setPauseAnnotation sets the reconcile-paused annotation on a live object
 // This annotation signals that reconciliation should stop due to an edit war
-func (p *Patcher) setPauseAnnotation(ctx context.Context, obj *unstructured.Unstructured) error {
+// The competing managers, if known, are recorded in the reconcile-paused-by annotation.
+// The pause episode is recorded too, and the auto-resume time if the policy schedules one.
+// Returns the cool-down before the automatic resume, 0 for a permanent pause.
+func (p *Patcher) setPauseAnnotation(ctx context.Context, obj *unstructured.Unstructured, competitors string) (time.Duration, error) {
 	// Get fresh copy to avoid conflicts
 	fresh := &unstructured.Unstructured{}
 	fresh.SetGroupVersionKind(obj.GroupVersionKind())
@@ -474,23 +1172,77 @@ func (p *Patcher) setPauseAnnotation(ctx context.Context, obj *unstructured.Unst
 	}
 
 	if err := p.client.Get(ctx, objKey, fresh); err != nil {
-		return fmt.Errorf("failed to get fresh copy for pause annotation: %w", err)
+		return 0, fmt.Errorf("failed to get fresh copy for pause annotation: %w", err)
 	}
 
+	// Count the episode: repeated pauses escalate to a permanent one
+	now := time.Now()
+	previous, previousAt := overrides.GetPauseEpisode(fresh)
+	episode := p.autoResume.NextEpisode(previous, previousAt, now)
+	coolDown, autoResume := p.autoResume.CoolDown(episode)
+
 	// Add pause annotation
 	annotations := fresh.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
 	annotations[overrides.AnnotationReconcilePaused] = "true"
+	annotations[overrides.AnnotationReconcilePausedAt] = now.UTC().Format(time.RFC3339)
+	annotations[overrides.AnnotationReconcilePauseEpisode] = strconv.Itoa(episode)
+	if autoResume {
+		annotations[overrides.AnnotationReconcileResumeAt] = now.Add(coolDown).UTC().Format(time.RFC3339)
+	} else {
+		delete(annotations, overrides.AnnotationReconcileResumeAt)
+	}
+	if competitors != "" {
+		annotations[overrides.AnnotationReconcilePausedBy] = competitors
+	}
 	fresh.SetAnnotations(annotations)
 
 	// Update the object
 	if err := p.client.Update(ctx, fresh); err != nil {
-		return fmt.Errorf("failed to set pause annotation: %w", err)
+		return 0, fmt.Errorf("failed to set pause annotation: %w", err)
 	}
 
-	return nil
+	return coolDown, nil
+}
+
+// resumePaused removes the pause annotations of a live object whose cool-down elapsed
+// The episode and pause time are kept, so a resource that thrashes again escalates.
+// Returns the updated object.
+func (p *Patcher) resumePaused(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	fresh := &unstructured.Unstructured{}
+	fresh.SetGroupVersionKind(obj.GroupVersionKind())
+	objKey := client.ObjectKey{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	if err := p.client.Get(ctx, objKey, fresh); err != nil {
+		return nil, fmt.Errorf("failed to get fresh copy to resume: %w", err)
+	}
+
+	annotations := fresh.GetAnnotations()
+	delete(annotations, overrides.AnnotationReconcilePaused)
+	delete(annotations, overrides.AnnotationReconcileResumeAt)
+	delete(annotations, overrides.AnnotationReconcilePausedBy)
+	fresh.SetAnnotations(annotations)
+
+	if err := p.client.Update(ctx, fresh); err != nil {
+		return nil, fmt.Errorf("failed to remove pause annotation: %w", err)
+	}
+
+	return fresh, nil
+}
+
+// recordThrottle counts a throttled update towards the edit war threshold
+// Returns true if the object must be paused. Objects whose kind doesn't pause on thrashing
+// are only held back until their token bucket refills.
+func (p *Patcher) recordThrottle(assetMeta *assets.AssetMetadata, kind, resourceKey string, threshold int) bool {
+	if !assetMeta.PausesOnThrashing(kind) {
+		return false
+	}
+	return p.thrashingDetector.RecordThrottleWithThreshold(resourceKey, threshold)
 }
 
 // countJSONPatchOperations counts the number of operations in a JSON patch string
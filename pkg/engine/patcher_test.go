@@ -17,7 +17,19 @@ limitations under the License.
 package engine
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 )
 
 func TestCountJSONPatchOperations(t *testing.T) {
@@ -79,3 +91,140 @@ func TestCountJSONPatchOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestInitialOverrides(t *testing.T) {
+	p := NewPatcher(fake.NewClientBuilder().Build(), nil, assets.NewLoader())
+	p.SetInitialOverrides(map[string]overrides.AssetOverrides{
+		"MachineConfig/90-worker-swap-online": {MergePatch: `{"spec": {"kernelArguments": ["iommu=pt"]}}`},
+	})
+
+	desired := &unstructured.Unstructured{}
+	desired.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	desired.SetKind("MachineConfig")
+	desired.SetName("90-worker-swap-online")
+
+	// A missing object stands in with the annotations it will be created with
+	live := &unstructured.Unstructured{}
+	live.SetAnnotations(p.initialOverrideAnnotations(desired))
+	source := p.overrideSource("swap-enable", live, false)
+	if source == nil || source.GetAnnotations()[overrides.MergePatchAnnotation] == "" {
+		t.Fatalf("overrideSource() = %v, want the initial merge patch", source)
+	}
+
+	other := desired.DeepCopy()
+	other.SetName("90-master-swap-online")
+	if annotations := p.initialOverrideAnnotations(other); annotations != nil {
+		t.Errorf("initialOverrideAnnotations() = %v, want none for another object", annotations)
+	}
+	if source := p.overrideSource("swap-enable", &unstructured.Unstructured{}, false); source != nil {
+		t.Errorf("overrideSource() = %v, want nil without overrides", source)
+	}
+}
+
+func TestAuditModeKeepsExpiredPause(t *testing.T) {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{
+		overrides.AnnotationReconcilePaused:       "true",
+		overrides.AnnotationReconcilePauseEpisode: "1",
+		overrides.AnnotationReconcileResumeAt:     time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+	})
+
+	var writes int
+	c := fake.NewClientBuilder().
+		WithObjects(hco.DeepCopy()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				writes++
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				writes++
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	hcoAsset, err := registry.GetAsset("hco-golden-config")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	p := NewPatcher(c, c, loader)
+	p.SetMode(ModeAudit)
+	p.SetAutoResumePolicy(throttling.AutoResumePolicy{Window: 15 * time.Minute, MaxEpisodes: 3})
+
+	applied, err := p.ReconcileAsset(context.Background(), hcoAsset, &pkgcontext.RenderContext{HCO: hco})
+	if err != nil {
+		t.Fatalf("ReconcileAsset() error = %v", err)
+	}
+	if applied {
+		t.Error("ReconcileAsset() applied in audit mode")
+	}
+	if writes != 0 {
+		t.Errorf("audit mode issued %d writes, want none", writes)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(hco), live); err != nil {
+		t.Fatalf("Failed to get HCO: %v", err)
+	}
+	if !overrides.IsPaused(live) {
+		t.Error("expired pause was resumed in audit mode")
+	}
+}
+
+func TestThrottledNodeLevelChangeDoesNotPause(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	p := NewPatcher(fake.NewClientBuilder().Build(), nil, assets.NewLoader())
+
+	tests := []struct {
+		asset     string
+		kind      string
+		wantPause bool
+	}{
+		{"swap-enable", "MachineConfig", false},
+		{"kubelet-perf-settings", "KubeletConfig", false},
+		{"prometheus-alerts", "PrometheusRule", true},
+		// Assets rendering mixed kinds throttle each object by its own kind
+		{"kubelet-cpu-manager", "KubeletConfig", false},
+		{"kubelet-cpu-manager", "MachineConfigPool", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.asset+"/"+tt.kind, func(t *testing.T) {
+			assetMeta, err := registry.GetAsset(tt.asset)
+			if err != nil {
+				t.Fatalf("Failed to get asset: %v", err)
+			}
+			capacity, window, threshold := assetMeta.ThrottlingLimits(tt.kind)
+			key := "test/" + tt.asset + "/" + tt.kind
+
+			// First legitimate change takes the window's tokens
+			for i := 0; i < capacity; i++ {
+				if err := p.throttle.RecordWithSettings(key, capacity, window); err != nil {
+					t.Fatalf("change %d throttled: %v", i+1, err)
+				}
+			}
+
+			// Second legitimate change within the window, retried with controller backoff
+			paused := false
+			for i := 0; i < 10*threshold; i++ {
+				if err := p.throttle.RecordWithSettings(key, capacity, window); !throttling.IsThrottled(err) {
+					t.Fatalf("retry %d not throttled: %v", i+1, err)
+				}
+				paused = paused || p.recordThrottle(assetMeta, tt.kind, key, threshold)
+			}
+			if paused != tt.wantPause {
+				t.Errorf("paused = %v, want %v", paused, tt.wantPause)
+			}
+		})
+	}
+}
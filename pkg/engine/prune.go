@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// setAssetLabel labels an object with the asset that renders it, so it can be pruned later
+func setAssetLabel(obj *unstructured.Unstructured, assetName string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[AssetLabel] = assetName
+	obj.SetLabels(labels)
+}
+
+// PruneAsset deletes the objects of a prune asset, for an asset whose conditions no longer hold
+// Assets without prune: true are left alone.
+func (p *Patcher) PruneAsset(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) error {
+	return p.pruneAsset(ctx, assetMeta, nil, renderCtx)
+}
+
+// pruneAsset deletes the objects of a prune asset that were not rendered in this pass
+// Objects carry the asset label, and are listed for every kind the asset template declares,
+// so objects of a kind the asset no longer renders at all are found too. Nothing is pruned
+// until every rendered object exists, e.g. so that a node's new pool exists before its old
+// one is deleted.
+func (p *Patcher) pruneAsset(ctx context.Context, assetMeta *assets.AssetMetadata, rendered []*unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) error {
+	if !assetMeta.Prune {
+		return nil
+	}
+
+	keep := make(map[string]bool, len(rendered))
+	for _, desired := range rendered {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		err := p.applier.Get(ctx, client.ObjectKeyFromObject(desired), live)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+		keep[pruneKey(desired)] = true
+	}
+
+	var errs []error
+	for _, gvk := range assetMeta.Kinds {
+		if gvk.Version == "" {
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := p.client.List(ctx, list, client.MatchingLabels{ManagedByLabel: ManagedByValue, AssetLabel: assetMeta.Name})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s objects of asset %s: %w", gvk.Kind, assetMeta.Name, err))
+			continue
+		}
+
+		for i := range list.Items {
+			if keep[pruneKey(&list.Items[i])] {
+				continue
+			}
+			if err := p.pruneObject(ctx, assetMeta, &list.Items[i], renderCtx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// pruneObject deletes an object its asset no longer renders
+// Unmanaged and paused objects are kept, audit mode only reports the deletion and
+// disruptive assets prune inside the maintenance window only, like any other change.
+func (p *Patcher) pruneObject(ctx context.Context, assetMeta *assets.AssetMetadata, obj *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) error {
+	logger := log.FromContext(ctx)
+
+	if overrides.IsUnmanaged(obj) || overrides.IsPaused(obj) {
+		logger.V(1).Info("Keeping object no longer rendered, it is unmanaged or paused",
+			"asset", assetMeta.Name,
+			"kind", obj.GetKind(),
+			"namespace", obj.GetNamespace(),
+			"objectName", obj.GetName(),
+		)
+		return nil
+	}
+
+	if p.mode == ModeAudit {
+		logger.Info("Audit mode: object no longer rendered would be pruned",
+			"asset", assetMeta.Name,
+			"kind", obj.GetKind(),
+			"namespace", obj.GetNamespace(),
+			"objectName", obj.GetName(),
+		)
+		return nil
+	}
+
+	if assetMeta.Disruptive && !p.inMaintenanceWindow(ctx, obj, renderCtx) {
+		return nil
+	}
+
+	if err := p.client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to prune %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	logger.Info("Pruned object no longer rendered",
+		"asset", assetMeta.Name,
+		"kind", obj.GetKind(),
+		"namespace", obj.GetNamespace(),
+		"objectName", obj.GetName(),
+	)
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.ObjectPruned(renderCtx.HCO, obj.GetKind(), obj.GetNamespace(), obj.GetName(), assetMeta.Name)
+	}
+	return nil
+}
+
+// pruneKey identifies an object among the objects of an asset
+func pruneKey(obj *unstructured.Unstructured) string {
+	return obj.GetKind() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
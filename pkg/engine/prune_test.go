@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var poolGVK = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"}
+
+func newAssetPool(name, asset string) *unstructured.Unstructured {
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(poolGVK)
+	pool.SetName(name)
+	pool.SetLabels(map[string]string{ManagedByLabel: ManagedByValue, AssetLabel: asset})
+	return pool
+}
+
+func TestPruneAsset(t *testing.T) {
+	assetMeta := &assets.AssetMetadata{
+		Name:  "kubelet-cpu-manager",
+		Prune: true,
+		Kinds: []schema.GroupVersionKind{poolGVK},
+	}
+	renderCtx := &pkgcontext.RenderContext{HCO: pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)}
+
+	exists := func(t *testing.T, c client.Client, name string) bool {
+		t.Helper()
+		err := c.Get(context.Background(), client.ObjectKey{Name: name}, newAssetPool(name, ""))
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatalf("Failed to get pool %s: %v", name, err)
+		}
+		return err == nil
+	}
+
+	t.Run("deletes objects no longer rendered", func(t *testing.T) {
+		unmanaged := newAssetPool("virt-amd-32c2t-128gi", assetMeta.Name)
+		unmanaged.SetAnnotations(map[string]string{overrides.AnnotationMode: overrides.ModeUnmanaged})
+		c := fake.NewClientBuilder().WithObjects(
+			newAssetPool("virt-intel-32c2t-128gi", assetMeta.Name),
+			newAssetPool("virt-intel-32c2t-256gi", assetMeta.Name),
+			newAssetPool("virt-intel-16c2t-64gi", "pci-passthrough"),
+			unmanaged,
+		).Build()
+		p := NewPatcher(c, c, assets.NewLoader())
+
+		rendered := []*unstructured.Unstructured{newAssetPool("virt-intel-32c2t-128gi", assetMeta.Name)}
+		if err := p.pruneAsset(context.Background(), assetMeta, rendered, renderCtx); err != nil {
+			t.Fatalf("pruneAsset() error = %v", err)
+		}
+
+		if exists(t, c, "virt-intel-32c2t-256gi") {
+			t.Error("stale pool was not pruned")
+		}
+		for _, name := range []string{"virt-intel-32c2t-128gi", "virt-intel-16c2t-64gi", "virt-amd-32c2t-128gi"} {
+			if !exists(t, c, name) {
+				t.Errorf("pool %s was pruned", name)
+			}
+		}
+	})
+
+	t.Run("waits for rendered objects to exist", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newAssetPool("virt-intel-32c2t-256gi", assetMeta.Name)).Build()
+		p := NewPatcher(c, c, assets.NewLoader())
+
+		rendered := []*unstructured.Unstructured{newAssetPool("virt-intel-32c2t-128gi", assetMeta.Name)}
+		if err := p.pruneAsset(context.Background(), assetMeta, rendered, renderCtx); err != nil {
+			t.Fatalf("pruneAsset() error = %v", err)
+		}
+		if !exists(t, c, "virt-intel-32c2t-256gi") {
+			t.Error("pool was pruned before its replacement exists")
+		}
+	})
+
+	t.Run("audit mode keeps objects", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newAssetPool("virt-intel-32c2t-256gi", assetMeta.Name)).Build()
+		p := NewPatcher(c, c, assets.NewLoader())
+		p.SetMode(ModeAudit)
+
+		if err := p.PruneAsset(context.Background(), assetMeta, renderCtx); err != nil {
+			t.Fatalf("PruneAsset() error = %v", err)
+		}
+		if !exists(t, c, "virt-intel-32c2t-256gi") {
+			t.Error("pool was pruned in audit mode")
+		}
+	})
+
+	t.Run("assets without prune keep objects", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newAssetPool("virt-intel-32c2t-256gi", assetMeta.Name)).Build()
+		p := NewPatcher(c, c, assets.NewLoader())
+
+		keep := *assetMeta
+		keep.Prune = false
+		if err := p.PruneAsset(context.Background(), &keep, renderCtx); err != nil {
+			t.Fatalf("PruneAsset() error = %v", err)
+		}
+		if !exists(t, c, "virt-intel-32c2t-256gi") {
+			t.Error("pool of an asset without prune was pruned")
+		}
+	})
+}
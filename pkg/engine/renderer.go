@@ -213,6 +213,10 @@ func (r *Renderer) customFuncMap() template.FuncMap {
 		// Usage: {{ has "value" .HCO.spec.featureGates }}
 		"has": has,
 
+		// crdExists checks if a CRD is installed
+		// Usage: {{ crdExists "fenceagentsremediationtemplates.fence-agents-remediation.medik8s.io" }}
+		"crdExists": r.crdExistsFunc(),
+
 		// crdEnum extracts enum values from a CRD field
 		// Usage: {{ crdEnum "kubedeschedulers.operator.openshift.io" "spec.profiles" }}
 		"crdEnum": r.crdEnumFunc(),
@@ -225,9 +229,18 @@ func (r *Renderer) customFuncMap() template.FuncMap {
 		// Usage: {{ objectExists "PrometheusRule" "openshift-kube-descheduler-operator" "descheduler-rules" }}
 		"objectExists": r.objectExistsFunc(),
 
+		// objectManaged checks if a Kubernetes object exists and carries the managed-by label,
+		// e.g. to leave alone singletons created by someone else
+		// Usage: {{ objectManaged "NMState" "" "nmstate" }}
+		"objectManaged": r.objectManagedFunc(),
+
 		// prometheusRuleHasRecordingRule checks if a PrometheusRule contains a specific recording rule
 		// Usage: {{ prometheusRuleHasRecordingRule "openshift-kube-descheduler-operator" "descheduler-rules" "descheduler:node:linear_amplified_ideal_point_positive_distance:k3:avg1m" }}
 		"prometheusRuleHasRecordingRule": r.prometheusRuleHasRecordingRuleFunc(),
+
+		// topologyGroups splits the worker nodes into groups sharing the kubelet reservations of their topology
+		// Usage: {{ range topologyGroups . }}{{ .ReservedSystemCPUs }}{{ end }}
+		"topologyGroups": topologyGroups,
 	}
 }
 
@@ -291,6 +304,18 @@ func has(needle interface{}, haystack interface{}) bool {
 	return false
 }
 
+// crdExistsFunc returns a function that checks if a CRD is installed
+func (r *Renderer) crdExistsFunc() func(string) bool {
+	return func(crdName string) bool {
+		if r.client == nil {
+			return false
+		}
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		return r.client.Get(context.Background(), types.NamespacedName{Name: crdName}, crd) == nil
+	}
+}
+
 // crdEnumFunc returns a function that extracts enum values from a CRD field
 func (r *Renderer) crdEnumFunc() func(string, string) []string {
 	return func(crdName, fieldPath string) []string {
@@ -431,21 +456,45 @@ func splitFieldPath(path string) []string {
 // objectExistsFunc returns a function that checks if a Kubernetes object exists
 func (r *Renderer) objectExistsFunc() func(string, string, string) bool {
 	return func(kind, namespace, name string) bool {
-		if r.client == nil {
-			return false
-		}
+		_, err := r.getObject(kind, namespace, name)
+		return err == nil
+	}
+}
 
-		obj := &unstructured.Unstructured{}
-		obj.SetKind(kind)
-		obj.SetAPIVersion("monitoring.coreos.com/v1") // Default for PrometheusRule
+// objectManagedFunc returns a function that checks if a Kubernetes object exists and is managed by the autopilot
+func (r *Renderer) objectManagedFunc() func(string, string, string) bool {
+	return func(kind, namespace, name string) bool {
+		obj, err := r.getObject(kind, namespace, name)
+		return err == nil && obj.GetLabels()[ManagedByLabel] == ManagedByValue
+	}
+}
 
-		err := r.client.Get(context.Background(), types.NamespacedName{
-			Namespace: namespace,
-			Name:      name,
-		}, obj)
+// objectAPIVersions maps the kinds templates look up to their API version
+// Other kinds default to PrometheusRule's monitoring.coreos.com/v1.
+var objectAPIVersions = map[string]string{
+	"NMState":                     "nmstate.io/v1",
+	"NetworkAttachmentDefinition": "k8s.cni.cncf.io/v1",
+}
 
-		return err == nil
+// getObject reads an object looked up by a template
+func (r *Renderer) getObject(kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("no client to get %s %s", kind, name)
+	}
+
+	apiVersion, ok := objectAPIVersions[kind]
+	if !ok {
+		apiVersion = "monitoring.coreos.com/v1" // Default for PrometheusRule
 	}
+	obj := &unstructured.Unstructured{}
+	obj.SetKind(kind)
+	obj.SetAPIVersion(apiVersion)
+
+	err := r.client.Get(context.Background(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, obj)
+	return obj, err
 }
 
 // prometheusRuleHasRecordingRuleFunc returns a function that checks if a PrometheusRule contains a recording rule
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func aaqRenderContext(enabled bool) *pkgcontext.RenderContext {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	if enabled {
+		hco.SetAnnotations(map[string]string{"platform.kubevirt.io/enable-aaq": "true"})
+	}
+	return &pkgcontext.RenderContext{HCO: hco}
+}
+
+func TestGoldenConfigApplicationAwareQuota(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		rendered := renderAssetByName(t, "hco-golden-config", aaqRenderContext(false))
+
+		if _, found, _ := unstructured.NestedBool(rendered.Object, "spec", "enableApplicationAwareQuota"); found {
+			t.Error("enableApplicationAwareQuota should be left to the HCO default")
+		}
+		if _, found, _ := unstructured.NestedMap(rendered.Object, "spec", "applicationAwareConfig"); found {
+			t.Error("applicationAwareConfig should not be rendered")
+		}
+	})
+
+	t.Run("enabled with annotation", func(t *testing.T) {
+		rendered := renderAssetByName(t, "hco-golden-config", aaqRenderContext(true))
+
+		enabled, _, _ := unstructured.NestedBool(rendered.Object, "spec", "enableApplicationAwareQuota")
+		if !enabled {
+			t.Error("enableApplicationAwareQuota should be true")
+		}
+		// The HCO owns the AAQ CR, so its configuration goes through the HCO spec
+		configName, _, _ := unstructured.NestedString(rendered.Object, "spec", "applicationAwareConfig", "vmiCalcConfigName")
+		if configName != "VirtualResources" {
+			t.Errorf("vmiCalcConfigName = %s, want VirtualResources", configName)
+		}
+		clusterQuota, found, _ := unstructured.NestedBool(rendered.Object, "spec", "applicationAwareConfig", "allowApplicationAwareClusterResourceQuota")
+		if !found || clusterQuota {
+			t.Error("allowApplicationAwareClusterResourceQuota should be false")
+		}
+	})
+}
@@ -48,6 +48,18 @@ func TestCRDIntrospectionFunctions(t *testing.T) {
 	renderer := NewRenderer(loader)
 	renderer.SetClient(fakeClient)
 
+	t.Run("crdExists checks CRD presence", func(t *testing.T) {
+		funcMap := renderer.customFuncMap()
+		crdExistsFunc := funcMap["crdExists"].(func(string) bool)
+
+		if !crdExistsFunc("kubedeschedulers.operator.openshift.io") {
+			t.Error("Expected kubedeschedulers CRD to exist")
+		}
+		if crdExistsFunc("nonexistent.example.com") {
+			t.Error("Expected nonexistent CRD to be missing")
+		}
+	})
+
 	t.Run("crdEnum extracts all enum values", func(t *testing.T) {
 		funcMap := renderer.customFuncMap()
 		crdEnumFunc := funcMap["crdEnum"].(func(string, string) []string)
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const (
+	nadCRD  = "network-attachment-definitions.k8s.cni.cncf.io"
+	nncpCRD = "nodenetworkconfigurationpolicies.nmstate.io"
+)
+
+func migrationNetworkRenderContext(value string) *pkgcontext.RenderContext {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	if value != "" {
+		hco.SetAnnotations(map[string]string{pkgcontext.MigrationNetworkAnnotation: value})
+	}
+	return &pkgcontext.RenderContext{HCO: hco}
+}
+
+func renderMigrationNetwork(t *testing.T, value string) []*unstructured.Unstructured {
+	t.Helper()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	asset, err := registry.GetAsset("migration-network")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	objs, err := NewRenderer(loader).RenderMultiAsset(asset, migrationNetworkRenderContext(value))
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+	return objs
+}
+
+func TestMigrationNetworkAsset(t *testing.T) {
+	objs := renderMigrationNetwork(t, "bond0:100=10.200.5.0/24")
+	if len(objs) != 3 {
+		t.Fatalf("Expected NMState, NNCP and NAD, got %d objects", len(objs))
+	}
+
+	wantKinds := []string{"NMState", "NodeNetworkConfigurationPolicy", "NetworkAttachmentDefinition"}
+	for i, obj := range objs {
+		if obj.GetKind() != wantKinds[i] {
+			t.Errorf("object %d kind = %s, want %s", i, obj.GetKind(), wantKinds[i])
+		}
+	}
+
+	nncp := objs[1]
+	interfaces, _, _ := unstructured.NestedSlice(nncp.Object, "spec", "desiredState", "interfaces")
+	if len(interfaces) != 1 {
+		t.Fatalf("Expected one interface, got %d", len(interfaces))
+	}
+	iface := interfaces[0].(map[string]interface{})
+	if iface["name"] != "bond0.100" {
+		t.Errorf("interface name = %v, want bond0.100", iface["name"])
+	}
+	if baseIface, _, _ := unstructured.NestedString(iface, "vlan", "base-iface"); baseIface != "bond0" {
+		t.Errorf("vlan.base-iface = %s, want bond0", baseIface)
+	}
+	if id, _, _ := unstructured.NestedInt64(iface, "vlan", "id"); id != 100 {
+		t.Errorf("vlan.id = %d, want 100", id)
+	}
+
+	nad := objs[2]
+	if nad.GetNamespace() != pkgcontext.DefaultHCONamespace {
+		t.Errorf("NAD namespace = %s, want the HCO namespace %s", nad.GetNamespace(), pkgcontext.DefaultHCONamespace)
+	}
+	configJSON, _, _ := unstructured.NestedString(nad.Object, "spec", "config")
+	var config struct {
+		Type   string `json:"type"`
+		Master string `json:"master"`
+		IPAM   struct {
+			Type  string `json:"type"`
+			Range string `json:"range"`
+		} `json:"ipam"`
+	}
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		t.Fatalf("NAD config is not valid JSON: %v", err)
+	}
+	if config.Master != "bond0.100" || config.IPAM.Type != "whereabouts" || config.IPAM.Range != "10.200.5.0/24" {
+		t.Errorf("unexpected NAD config: %s", configJSON)
+	}
+}
+
+func TestMigrationNetworkAssetInvalidAnnotation(t *testing.T) {
+	if objs := renderMigrationNetwork(t, "bond0"); len(objs) != 0 {
+		t.Errorf("Expected no objects for an invalid annotation, got %d", len(objs))
+	}
+}
+
+func TestMigrationNetworkAssetExistingNMState(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	asset, err := registry.GetAsset("migration-network")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		wantKinds []string
+	}{
+		{"created by someone else", nil, []string{"NodeNetworkConfigurationPolicy", "NetworkAttachmentDefinition"}},
+		{"created by the autopilot", map[string]string{ManagedByLabel: ManagedByValue},
+			[]string{"NMState", "NodeNetworkConfigurationPolicy", "NetworkAttachmentDefinition"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nmstate := &unstructured.Unstructured{}
+			nmstate.SetAPIVersion("nmstate.io/v1")
+			nmstate.SetKind("NMState")
+			nmstate.SetName("nmstate")
+			nmstate.SetLabels(tt.labels)
+
+			renderer := NewRenderer(loader)
+			renderer.SetClient(fake.NewClientBuilder().WithObjects(nmstate).Build())
+			objs, err := renderer.RenderMultiAsset(asset, migrationNetworkRenderContext("bond0:100"))
+			if err != nil {
+				t.Fatalf("Failed to render asset: %v", err)
+			}
+
+			var kinds []string
+			for _, obj := range objs {
+				kinds = append(kinds, obj.GetKind())
+			}
+			if !reflect.DeepEqual(kinds, tt.wantKinds) {
+				t.Errorf("rendered kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+		})
+	}
+}
+
+func TestGoldenConfigMigrationNetwork(t *testing.T) {
+	nad := &unstructured.Unstructured{}
+	nad.SetAPIVersion("k8s.cni.cncf.io/v1")
+	nad.SetKind("NetworkAttachmentDefinition")
+	nad.SetNamespace(pkgcontext.DefaultHCONamespace)
+	nad.SetName(pkgcontext.MigrationNetworkName)
+	crds := []string{nadCRD, nncpCRD}
+
+	tests := []struct {
+		name  string
+		value string
+		objs  []client.Object
+		crds  []string
+		want  string
+	}{
+		{"no annotation", "", []client.Object{nad}, crds, ""},
+		{"NNCP CRD missing", "bond0:100", []client.Object{nad}, []string{nadCRD}, ""},
+		{"NAD CRD missing", "bond0:100", nil, []string{nncpCRD}, ""},
+		{"NAD not created yet", "bond0:100", nil, crds, ""},
+		{"wired to the NAD", "bond0:100", []client.Object{nad}, crds, pkgcontext.MigrationNetworkName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := renderAssetWithObjects(t, "hco-golden-config", migrationNetworkRenderContext(tt.value), tt.objs, tt.crds...)
+
+			network, _, _ := unstructured.NestedString(rendered.Object, "spec", "liveMigrationConfig", "network")
+			if network != tt.want {
+				t.Errorf("liveMigrationConfig.network = %q, want %q", network, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func oadpRenderContext(annotations map[string]string) *pkgcontext.RenderContext {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(annotations)
+	return &pkgcontext.RenderContext{HCO: hco}
+}
+
+func TestOADPDataProtectionApplication(t *testing.T) {
+	rendered := renderAssetByName(t, "oadp-backup", oadpRenderContext(map[string]string{
+		"platform.kubevirt.io/backup-secret": "cloud-credentials",
+		"platform.kubevirt.io/backup-bucket": "vm-backups/cluster-a/daily",
+		"platform.kubevirt.io/backup-region": "eu-west-1",
+	}))
+
+	if rendered.GetKind() != "DataProtectionApplication" || rendered.GetNamespace() != "openshift-adp" {
+		t.Fatalf("unexpected object %s %s/%s", rendered.GetKind(), rendered.GetNamespace(), rendered.GetName())
+	}
+
+	plugins, _, _ := unstructured.NestedStringSlice(rendered.Object, "spec", "configuration", "velero", "defaultPlugins")
+	if want := []string{"openshift", "kubevirt", "csi", "aws"}; !reflect.DeepEqual(plugins, want) {
+		t.Errorf("defaultPlugins = %v, want %v", plugins, want)
+	}
+
+	// Built-in data mover
+	if moveData, _, _ := unstructured.NestedBool(rendered.Object, "spec", "configuration", "velero", "defaultSnapshotMoveData"); !moveData {
+		t.Error("defaultSnapshotMoveData should be true")
+	}
+	if enabled, _, _ := unstructured.NestedBool(rendered.Object, "spec", "configuration", "nodeAgent", "enable"); !enabled {
+		t.Error("nodeAgent should be enabled")
+	}
+	if uploader, _, _ := unstructured.NestedString(rendered.Object, "spec", "configuration", "nodeAgent", "uploaderType"); uploader != "kopia" {
+		t.Errorf("uploaderType = %s, want kopia", uploader)
+	}
+
+	locations, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "backupLocations")
+	if len(locations) != 1 {
+		t.Fatalf("Expected one backup location, got %d", len(locations))
+	}
+	velero := locations[0].(map[string]interface{})["velero"].(map[string]interface{})
+	if secret, _, _ := unstructured.NestedString(velero, "credential", "name"); secret != "cloud-credentials" {
+		t.Errorf("credential.name = %s, want cloud-credentials", secret)
+	}
+	if key, _, _ := unstructured.NestedString(velero, "credential", "key"); key != "cloud" {
+		t.Errorf("credential.key = %s, want cloud", key)
+	}
+	if region, _, _ := unstructured.NestedString(velero, "config", "region"); region != "eu-west-1" {
+		t.Errorf("config.region = %s, want eu-west-1", region)
+	}
+	if bucket, _, _ := unstructured.NestedString(velero, "objectStorage", "bucket"); bucket != "vm-backups" {
+		t.Errorf("bucket = %s, want vm-backups", bucket)
+	}
+	if prefix, _, _ := unstructured.NestedString(velero, "objectStorage", "prefix"); prefix != "cluster-a/daily" {
+		t.Errorf("prefix = %s, want cluster-a/daily", prefix)
+	}
+}
+
+func TestOADPProviderAndMissingBucket(t *testing.T) {
+	rendered := renderAssetByName(t, "oadp-backup", oadpRenderContext(map[string]string{
+		"platform.kubevirt.io/backup-secret":   "cloud-credentials",
+		"platform.kubevirt.io/backup-provider": "gcp",
+	}))
+
+	plugins, _, _ := unstructured.NestedStringSlice(rendered.Object, "spec", "configuration", "velero", "defaultPlugins")
+	if !reflect.DeepEqual(plugins, []string{"openshift", "kubevirt", "csi", "gcp"}) {
+		t.Errorf("defaultPlugins = %v, want the gcp provider plugin", plugins)
+	}
+
+	if _, found, _ := unstructured.NestedSlice(rendered.Object, "spec", "backupLocations"); found {
+		t.Error("backupLocations should not be rendered without a bucket")
+	}
+	if noDefault, _, _ := unstructured.NestedBool(rendered.Object, "spec", "configuration", "velero", "noDefaultBackupLocation"); !noDefault {
+		t.Error("noDefaultBackupLocation should be true without a bucket")
+	}
+}
+
+func TestOADPBackupLocationSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantLocation bool
+		wantKey      string
+		wantRegion   string
+	}{
+		{
+			name: "aws without region",
+			annotations: map[string]string{
+				"platform.kubevirt.io/backup-secret": "cloud-credentials",
+				"platform.kubevirt.io/backup-bucket": "vm-backups",
+			},
+		},
+		{
+			name: "aws with region and credential key",
+			annotations: map[string]string{
+				"platform.kubevirt.io/backup-secret":     "cloud-credentials",
+				"platform.kubevirt.io/backup-bucket":     "vm-backups",
+				"platform.kubevirt.io/backup-region":     "us-east-2",
+				"platform.kubevirt.io/backup-secret-key": "credentials",
+			},
+			wantLocation: true,
+			wantKey:      "credentials",
+			wantRegion:   "us-east-2",
+		},
+		{
+			name: "gcp needs no region",
+			annotations: map[string]string{
+				"platform.kubevirt.io/backup-secret":   "cloud-credentials",
+				"platform.kubevirt.io/backup-bucket":   "vm-backups",
+				"platform.kubevirt.io/backup-provider": "gcp",
+			},
+			wantLocation: true,
+			wantKey:      "cloud",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := renderAssetByName(t, "oadp-backup", oadpRenderContext(tt.annotations))
+
+			locations, found, _ := unstructured.NestedSlice(rendered.Object, "spec", "backupLocations")
+			noDefault, _, _ := unstructured.NestedBool(rendered.Object, "spec", "configuration", "velero", "noDefaultBackupLocation")
+			if !tt.wantLocation {
+				if found || !noDefault {
+					t.Errorf("backupLocations = %v, noDefaultBackupLocation = %v, want no backup location", locations, noDefault)
+				}
+				return
+			}
+			if len(locations) != 1 || noDefault {
+				t.Fatalf("backupLocations = %v, noDefaultBackupLocation = %v, want one backup location", locations, noDefault)
+			}
+
+			velero := locations[0].(map[string]interface{})["velero"].(map[string]interface{})
+			if key, _, _ := unstructured.NestedString(velero, "credential", "key"); key != tt.wantKey {
+				t.Errorf("credential.key = %s, want %s", key, tt.wantKey)
+			}
+			region, found, _ := unstructured.NestedString(velero, "config", "region")
+			if region != tt.wantRegion || found != (tt.wantRegion != "") {
+				t.Errorf("config.region = %q, want %q", region, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestOADPQuotesAnnotationValues(t *testing.T) {
+	// Values YAML would read as numbers or booleans must stay strings
+	rendered := renderAssetByName(t, "oadp-backup", oadpRenderContext(map[string]string{
+		"platform.kubevirt.io/backup-secret":     "true",
+		"platform.kubevirt.io/backup-bucket":     "2026/0123",
+		"platform.kubevirt.io/backup-region":     "no",
+		"platform.kubevirt.io/backup-secret-key": "1",
+	}))
+
+	locations, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "backupLocations")
+	if len(locations) != 1 {
+		t.Fatalf("backupLocations = %v, want one backup location", locations)
+	}
+	velero := locations[0].(map[string]interface{})["velero"].(map[string]interface{})
+	for _, field := range []struct {
+		path []string
+		want string
+	}{
+		{[]string{"credential", "name"}, "true"},
+		{[]string{"credential", "key"}, "1"},
+		{[]string{"config", "region"}, "no"},
+		{[]string{"objectStorage", "bucket"}, "2026"},
+		{[]string{"objectStorage", "prefix"}, "0123"},
+	} {
+		if got, _, err := unstructured.NestedString(velero, field.path...); err != nil || got != field.want {
+			t.Errorf("%v = %q (%v), want the string %q", field.path, got, err, field.want)
+		}
+	}
+}
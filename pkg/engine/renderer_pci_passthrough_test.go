@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func renderPCIPassthrough(t *testing.T, nodes []pkgcontext.NodeInfo, featureGates ...string) []*unstructured.Unstructured {
+	t.Helper()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	asset, err := registry.GetAsset("pci-passthrough")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	if len(featureGates) > 0 {
+		if err := unstructured.SetNestedStringSlice(hco.Object, featureGates, "spec", "featureGates"); err != nil {
+			t.Fatalf("Failed to set feature gates: %v", err)
+		}
+	}
+	renderCtx := &pkgcontext.RenderContext{
+		HCO:      hco,
+		Hardware: &pkgcontext.HardwareContext{PCIDevicesPresent: true},
+		Nodes:    nodes,
+	}
+
+	objs, err := NewRenderer(loader).RenderMultiAsset(asset, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+	return objs
+}
+
+func kernelArguments(t *testing.T, obj *unstructured.Unstructured) []string {
+	t.Helper()
+
+	args, _, err := unstructured.NestedStringSlice(obj.Object, "spec", "kernelArguments")
+	if err != nil {
+		t.Fatalf("Error accessing kernelArguments: %v", err)
+	}
+	return args
+}
+
+func TestPCIPassthroughSingleVendor(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []pkgcontext.NodeInfo
+		wantArgs []string
+	}{
+		{
+			name:     "unknown vendor defaults to intel",
+			nodes:    []pkgcontext.NodeInfo{{Name: "worker-0", Roles: []string{"worker"}}},
+			wantArgs: []string{"intel_iommu=on", "iommu=pt"},
+		},
+		{
+			name: "intel workers",
+			nodes: []pkgcontext.NodeInfo{
+				{Name: "worker-0", Roles: []string{"worker"}, CPUVendor: "intel", CPUVendorID: "Intel"},
+			},
+			wantArgs: []string{"intel_iommu=on", "iommu=pt"},
+		},
+		{
+			name: "amd workers with intel control plane",
+			nodes: []pkgcontext.NodeInfo{
+				{Name: "master-0", Roles: []string{"master"}, CPUVendor: "intel", CPUVendorID: "Intel"},
+				{Name: "worker-0", Roles: []string{"worker"}, CPUVendor: "amd", CPUVendorID: "AMD"},
+			},
+			wantArgs: []string{"iommu=pt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := renderPCIPassthrough(t, tt.nodes)
+			if len(objs) != 1 {
+				t.Fatalf("Rendered %d objects, want 1", len(objs))
+			}
+			if objs[0].GetName() != "50-virt-pci-passthrough" {
+				t.Errorf("name = %s, want 50-virt-pci-passthrough", objs[0].GetName())
+			}
+
+			args := kernelArguments(t, objs[0])
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("kernelArguments = %v, want %v", args, tt.wantArgs)
+			}
+			for i := range tt.wantArgs {
+				if args[i] != tt.wantArgs[i] {
+					t.Errorf("kernelArguments = %v, want %v", args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+func TestPCIPassthroughMixedVendors(t *testing.T) {
+	objs := renderPCIPassthrough(t, []pkgcontext.NodeInfo{
+		{Name: "worker-0", Roles: []string{"worker"}, CPUVendor: "intel", CPUVendorID: "Intel"},
+		{Name: "worker-1", Roles: []string{"worker"}, CPUVendor: "intel", CPUVendorID: "GenuineIntel"},
+		{Name: "worker-2", Roles: []string{"worker"}, CPUVendor: "amd", CPUVendorID: "AuthenticAMD"},
+	})
+
+	// Shared worker MachineConfig + pool and MachineConfig of the Intel workers,
+	// the AMD IOMMU needs no kernel argument
+	if len(objs) != 3 {
+		t.Fatalf("Rendered %d objects, want 3", len(objs))
+	}
+
+	if args := kernelArguments(t, objs[0]); len(args) != 1 || args[0] != "iommu=pt" {
+		t.Errorf("shared worker kernelArguments = %v, want [iommu=pt]", args)
+	}
+
+	byName := make(map[string]*unstructured.Unstructured)
+	for _, obj := range objs {
+		byName[obj.GetKind()+"/"+obj.GetName()] = obj
+	}
+
+	pool, ok := byName["MachineConfigPool/virt-intel"]
+	if !ok {
+		t.Fatal("missing MachineConfigPool virt-intel")
+	}
+	mc, ok := byName["MachineConfig/50-virt-pci-passthrough-intel"]
+	if !ok {
+		t.Fatal("missing MachineConfig 50-virt-pci-passthrough-intel")
+	}
+
+	if role := mc.GetLabels()["machineconfiguration.openshift.io/role"]; role != "virt-intel" {
+		t.Errorf("intel MachineConfig role = %s, want virt-intel", role)
+	}
+	if args := kernelArguments(t, mc); len(args) != 1 || args[0] != "intel_iommu=on" {
+		t.Errorf("intel kernelArguments = %v, want [intel_iommu=on]", args)
+	}
+
+	exprs, _, _ := unstructured.NestedSlice(pool.Object, "spec", "nodeSelector", "matchExpressions")
+	if len(exprs) != 1 {
+		t.Fatalf("intel pool matchExpressions = %v, want 1 entry", exprs)
+	}
+	values, _, _ := unstructured.NestedStringSlice(exprs[0].(map[string]interface{}), "values")
+	if len(values) != 2 {
+		t.Errorf("intel pool vendor IDs = %v, want 2 values", values)
+	}
+}
+
+func TestPCIPassthroughMixedVendorsWithTopologyPools(t *testing.T) {
+	nodes := []pkgcontext.NodeInfo{
+		{Name: "worker-0", Roles: []string{"worker"}, CPUVendor: "intel", CPUVendorID: "Intel", CPUs: 64, ThreadsPerCore: 2, Memory: 128 << 30},
+		{Name: "worker-1", Roles: []string{"worker"}, CPUVendor: "amd", CPUVendorID: "AMD", CPUs: 64, ThreadsPerCore: 2, Memory: 128 << 30},
+	}
+
+	// A node can only belong to one custom pool: the topology pools of kubelet-cpu-manager
+	// select the virt-intel MachineConfig instead of a virt-intel pool
+	objs := renderPCIPassthrough(t, nodes, "CPUManager")
+	if len(objs) != 2 {
+		t.Fatalf("Rendered %d objects, want the shared and the intel MachineConfig", len(objs))
+	}
+	for _, obj := range objs {
+		if obj.GetKind() != "MachineConfig" {
+			t.Errorf("unexpected %s/%s", obj.GetKind(), obj.GetName())
+		}
+	}
+	if role := objs[1].GetLabels()[MachineConfigRoleLabel]; role != "virt-intel" {
+		t.Errorf("intel MachineConfig role = %s, want virt-intel", role)
+	}
+
+	groups := groupByTopology(nodes)
+	if len(groups) != 2 || groups[1].Pool != "virt-intel-32c2t-128gi" || groups[1].Vendor != "intel" {
+		t.Errorf("groupByTopology() = %+v, want an intel topology pool", groups)
+	}
+
+	// Without the CPU Manager the vendor pool is rendered
+	if objs := renderPCIPassthrough(t, nodes); len(objs) != 3 {
+		t.Errorf("Rendered %d objects without CPUManager, want 3", len(objs))
+	}
+}
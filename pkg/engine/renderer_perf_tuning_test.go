@@ -17,6 +17,7 @@ limitations under the License.
 package engine
 
 import (
+	"encoding/base64"
 	"strings"
 	"testing"
 
@@ -393,6 +394,160 @@ func TestCPUManagerReservedCPUs(t *testing.T) {
 	}
 }
 
+func TestCPUManagerReservationsFromTopology(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	renderer := NewRenderer(loader)
+	renderCtx := &pkgcontext.RenderContext{
+		HCO:      pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"),
+		Hardware: &pkgcontext.HardwareContext{NUMANodesPresent: true},
+		Nodes: []pkgcontext.NodeInfo{
+			{Name: "master-0", Roles: []string{"master"}, CPUs: 8, ThreadsPerCore: 2, Memory: 32 << 30},
+			{Name: "worker-0", Roles: []string{"worker"}, CPUs: 256, ThreadsPerCore: 2, Memory: 128 << 30},
+		},
+	}
+
+	cpuManager, err := registry.GetAsset("kubelet-cpu-manager")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+	rendered, err := renderer.RenderAsset(cpuManager, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+
+	reservedCPUs, _, _ := unstructured.NestedString(rendered.Object, "spec", "kubeletConfig", "reservedSystemCPUs")
+	if reservedCPUs != "0-3,128-131" {
+		t.Errorf("reservedSystemCPUs = %s, want 0-3,128-131", reservedCPUs)
+	}
+
+	reservedMem, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "kubeletConfig", "reservedMemory")
+	memory, _, _ := unstructured.NestedString(reservedMem[0].(map[string]interface{}), "limits", "memory")
+	if memory != "9643.68Mi" {
+		t.Errorf("reservedMemory = %s, want 9643.68Mi", memory)
+	}
+
+	// Workload pinning must use the same cpuset as the kubelet
+	numa, err := registry.GetAsset("numa-topology")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+	rendered, err = renderer.RenderAsset(numa, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+
+	files, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "config", "storage", "files")
+	source, _, _ := unstructured.NestedString(files[0].(map[string]interface{}), "contents", "source")
+	encoded := strings.TrimPrefix(source, "data:text/plain;charset=utf-8;base64,")
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode workload pinning file: %v", err)
+	}
+	if string(decoded) != `{"management":{"cpuset":"0-3,128-131"}}` {
+		t.Errorf("workload pinning = %s, want cpuset 0-3,128-131", decoded)
+	}
+}
+
+func TestCPUManagerHeterogeneousWorkers(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	renderer := NewRenderer(loader)
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	if err := unstructured.SetNestedStringSlice(hco.Object, []string{"CPUManager"}, "spec", "featureGates"); err != nil {
+		t.Fatalf("Failed to set feature gates: %v", err)
+	}
+	renderCtx := &pkgcontext.RenderContext{
+		HCO:      hco,
+		Hardware: &pkgcontext.HardwareContext{NUMANodesPresent: true},
+		Nodes: []pkgcontext.NodeInfo{
+			{Name: "worker-0", Roles: []string{"worker"}, CPUs: 64, ThreadsPerCore: 2, Memory: 128 << 30},
+			{Name: "worker-1", Roles: []string{"worker"}, CPUs: 512, ThreadsPerCore: 2, Memory: 1024 << 30},
+		},
+	}
+
+	cpuManager, err := registry.GetAsset("kubelet-cpu-manager")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+	objs, err := renderer.RenderMultiAsset(cpuManager, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+
+	// A pool and a KubeletConfig per topology group, nothing on the worker pool
+	if len(objs) != 4 {
+		t.Fatalf("Rendered %d objects, want 4", len(objs))
+	}
+
+	tests := []struct {
+		pool, node, cpus, memory string
+	}{
+		{"virt-32c2t-128gi", "worker-0", "0-1,32-33", "9643.68Mi"},
+		{"virt-256c2t-1024gi", "worker-1", "0-7,256-263", "27993.76Mi"},
+	}
+	for i, tt := range tests {
+		pool, kubeletConfig := objs[2*i], objs[2*i+1]
+		if pool.GetKind() != "MachineConfigPool" || pool.GetName() != tt.pool {
+			t.Fatalf("object %d = %s/%s, want MachineConfigPool/%s", 2*i, pool.GetKind(), pool.GetName(), tt.pool)
+		}
+		exprs, _, _ := unstructured.NestedSlice(pool.Object, "spec", "nodeSelector", "matchExpressions")
+		hosts, _, _ := unstructured.NestedStringSlice(exprs[0].(map[string]interface{}), "values")
+		if len(hosts) != 1 || hosts[0] != tt.node {
+			t.Errorf("%s nodes = %v, want [%s]", tt.pool, hosts, tt.node)
+		}
+
+		selector, _, _ := unstructured.NestedStringMap(kubeletConfig.Object, "spec", "machineConfigPoolSelector", "matchLabels")
+		if _, ok := selector["pools.operator.machineconfiguration.openshift.io/"+tt.pool]; !ok || len(selector) != 1 {
+			t.Errorf("%s KubeletConfig selector = %v, want its pool", tt.pool, selector)
+		}
+		if _, ok := pool.GetLabels()["pools.operator.machineconfiguration.openshift.io/"+tt.pool]; !ok {
+			t.Errorf("%s pool labels = %v, want the pool selector label", tt.pool, pool.GetLabels())
+		}
+		cpus, _, _ := unstructured.NestedString(kubeletConfig.Object, "spec", "kubeletConfig", "reservedSystemCPUs")
+		if cpus != tt.cpus {
+			t.Errorf("%s reservedSystemCPUs = %s, want %s", tt.pool, cpus, tt.cpus)
+		}
+		reservedMem, _, _ := unstructured.NestedSlice(kubeletConfig.Object, "spec", "kubeletConfig", "reservedMemory")
+		memory, _, _ := unstructured.NestedString(reservedMem[0].(map[string]interface{}), "limits", "memory")
+		if memory != tt.memory {
+			t.Errorf("%s reservedMemory = %s, want %s", tt.pool, memory, tt.memory)
+		}
+	}
+
+	// Workload pinning follows the same groups
+	numa, err := registry.GetAsset("numa-topology")
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+	objs, err = renderer.RenderMultiAsset(numa, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("Rendered %d workload pinning MachineConfigs, want 2", len(objs))
+	}
+	for i, tt := range tests {
+		if role := objs[i].GetLabels()[MachineConfigRoleLabel]; role != tt.pool {
+			t.Errorf("workload pinning %s role = %s, want %s", objs[i].GetName(), role, tt.pool)
+		}
+		files, _, _ := unstructured.NestedSlice(objs[i].Object, "spec", "config", "storage", "files")
+		source, _, _ := unstructured.NestedString(files[0].(map[string]interface{}), "contents", "source")
+		decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(source, "data:text/plain;charset=utf-8;base64,"))
+		if want := `{"management":{"cpuset":"` + tt.cpus + `"}}`; string(decoded) != want {
+			t.Errorf("workload pinning %s = %s, want %s", objs[i].GetName(), decoded, want)
+		}
+	}
+}
+
 func TestCPUManagerDocumentation(t *testing.T) {
 	_, loader, asset := renderHCOAsset(t, "kubelet-cpu-manager")
 
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const farTemplateCRD = "fenceagentsremediationtemplates.fence-agents-remediation.medik8s.io"
+
+func renderAssetWithCRDs(t *testing.T, name string, renderCtx *pkgcontext.RenderContext, crds ...string) *unstructured.Unstructured {
+	t.Helper()
+	return renderAssetWithObjects(t, name, renderCtx, nil, crds...)
+}
+
+func renderAssetWithObjects(t *testing.T, name string, renderCtx *pkgcontext.RenderContext, objs []client.Object, crds ...string) *unstructured.Unstructured {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	for _, crd := range crds {
+		objs = append(objs, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crd}})
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	asset, err := registry.GetAsset(name)
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	renderer := NewRenderer(loader)
+	renderer.SetClient(fakeClient)
+
+	rendered, err := renderer.RenderAsset(asset, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+	return rendered
+}
+
+func renderRemediationAsset(t *testing.T, name string, annotations map[string]string, crds ...string) *unstructured.Unstructured {
+	t.Helper()
+
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(annotations)
+	return renderAssetWithCRDs(t, name, &pkgcontext.RenderContext{HCO: hco}, crds...)
+}
+
+func TestNodeHealthCheckRemediation(t *testing.T) {
+	farAnnotation := map[string]string{"platform.kubevirt.io/fence-agents-secret": "bmc-credentials"}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		crds           []string
+		wantEscalating bool
+	}{
+		{"self node remediation by default", nil, []string{farTemplateCRD}, false},
+		{"FAR CRD missing", farAnnotation, nil, false},
+		{"escalates to FAR when enabled", farAnnotation, []string{farTemplateCRD}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := renderRemediationAsset(t, "node-health-check", tt.annotations, tt.crds...)
+
+			_, hasTemplate, _ := unstructured.NestedMap(rendered.Object, "spec", "remediationTemplate")
+			escalating, hasEscalating, _ := unstructured.NestedSlice(rendered.Object, "spec", "escalatingRemediations")
+
+			// The two fields are mutually exclusive
+			if hasTemplate == hasEscalating {
+				t.Fatalf("remediationTemplate present = %v, escalatingRemediations present = %v", hasTemplate, hasEscalating)
+			}
+			if hasEscalating != tt.wantEscalating {
+				t.Fatalf("escalatingRemediations present = %v, want %v", hasEscalating, tt.wantEscalating)
+			}
+			if !tt.wantEscalating {
+				return
+			}
+
+			if len(escalating) != 2 {
+				t.Fatalf("escalatingRemediations length = %d, want 2", len(escalating))
+			}
+			wantKinds := []string{"SelfNodeRemediationTemplate", "FenceAgentsRemediationTemplate"}
+			for i, item := range escalating {
+				entry := item.(map[string]interface{})
+				if order, _, _ := unstructured.NestedInt64(entry, "order"); order != int64(i) {
+					t.Errorf("escalatingRemediations[%d].order = %d, want %d", i, order, i)
+				}
+				if kind, _, _ := unstructured.NestedString(entry, "remediationTemplate", "kind"); kind != wantKinds[i] {
+					t.Errorf("escalatingRemediations[%d] kind = %s, want %s", i, kind, wantKinds[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFenceAgentsRemediationTemplate(t *testing.T) {
+	rendered := renderRemediationAsset(t, "fence-agents-remediation", map[string]string{
+		"platform.kubevirt.io/fence-agents-secret": "bmc-credentials",
+		"platform.kubevirt.io/fence-agent":         "fence_redfish",
+	})
+
+	if rendered.GetKind() != "FenceAgentsRemediationTemplate" {
+		t.Errorf("Kind = %s, want FenceAgentsRemediationTemplate", rendered.GetKind())
+	}
+	// Name must match the reference in the NodeHealthCheck
+	if rendered.GetName() != "virt-fence-agents-remediation-template" {
+		t.Errorf("Name = %s, want virt-fence-agents-remediation-template", rendered.GetName())
+	}
+
+	spec, _, _ := unstructured.NestedMap(rendered.Object, "spec", "template", "spec")
+	if spec["sharedSecretName"] != "bmc-credentials" {
+		t.Errorf("sharedSecretName = %v, want bmc-credentials", spec["sharedSecretName"])
+	}
+	if spec["agent"] != "fence_redfish" {
+		t.Errorf("agent = %v, want fence_redfish", spec["agent"])
+	}
+	if spec["remediationStrategy"] != "OutOfServiceTaint" {
+		t.Errorf("remediationStrategy = %v, want OutOfServiceTaint", spec["remediationStrategy"])
+	}
+
+	rendered = renderRemediationAsset(t, "fence-agents-remediation", map[string]string{
+		"platform.kubevirt.io/fence-agents-secret": "bmc-credentials",
+	})
+	if agent, _, _ := unstructured.NestedString(rendered.Object, "spec", "template", "spec", "agent"); agent != "fence_ipmilan" {
+		t.Errorf("default agent = %s, want fence_ipmilan", agent)
+	}
+}
+
+func TestFenceAgentsRemediationNodeParameters(t *testing.T) {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{"platform.kubevirt.io/fence-agents-secret": "bmc-credentials"})
+	rendered := renderAssetWithCRDs(t, "fence-agents-remediation", &pkgcontext.RenderContext{
+		HCO: hco,
+		Nodes: []pkgcontext.NodeInfo{
+			{Name: "worker-0", BMCAddress: "10.0.0.10", BMCPort: "623"},
+			{Name: "worker-1", BMCAddress: "fd00::11"},
+			{Name: "worker-2"},
+		},
+	})
+
+	ips, _, _ := unstructured.NestedStringMap(rendered.Object, "spec", "template", "spec", "nodeparameters", "--ip")
+	if len(ips) != 2 || ips["worker-0"] != "10.0.0.10" || ips["worker-1"] != "fd00::11" {
+		t.Errorf("--ip = %v, want the BMC address of worker-0 and worker-1", ips)
+	}
+	ports, _, _ := unstructured.NestedStringMap(rendered.Object, "spec", "template", "spec", "nodeparameters", "--ipport")
+	if len(ports) != 1 || ports["worker-0"] != "623" {
+		t.Errorf("--ipport = %v, want 623 for worker-0", ports)
+	}
+
+	// Without BMC annotations there are no node parameters
+	rendered = renderRemediationAsset(t, "fence-agents-remediation", map[string]string{
+		"platform.kubevirt.io/fence-agents-secret": "bmc-credentials",
+	})
+	if _, found, _ := unstructured.NestedMap(rendered.Object, "spec", "template", "spec", "nodeparameters"); found {
+		t.Error("nodeparameters rendered without BMC annotations")
+	}
+}
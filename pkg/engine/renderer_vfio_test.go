@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func vfioRenderContext(allowlist string) *pkgcontext.RenderContext {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	if allowlist != "" {
+		hco.SetAnnotations(map[string]string{pkgcontext.VFIODevicesAnnotation: allowlist})
+	}
+
+	return &pkgcontext.RenderContext{
+		HCO:      hco,
+		Hardware: &pkgcontext.HardwareContext{VFIOCapable: true},
+		Nodes: []pkgcontext.NodeInfo{
+			{Name: "worker-0", Roles: []string{"worker"}, IOMMUEnabled: true, PCIDevices: []string{"10de:1eb8", "10de:2236"}},
+		},
+	}
+}
+
+func renderAssetByName(t *testing.T, name string, renderCtx *pkgcontext.RenderContext) *unstructured.Unstructured {
+	t.Helper()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	asset, err := registry.GetAsset(name)
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	rendered, err := NewRenderer(loader).RenderAsset(asset, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render asset: %v", err)
+	}
+	return rendered
+}
+
+func TestVFIOAssignMachineConfig(t *testing.T) {
+	rendered := renderAssetByName(t, "vfio-assign", vfioRenderContext("10de:2236,10de:1eb8=nvidia.com/TU104GL_Tesla_T4,1002:7408"))
+	if rendered == nil {
+		t.Fatal("vfio-assign should render when allowlisted devices are detected")
+	}
+
+	if rendered.GetKind() != "MachineConfig" {
+		t.Errorf("Kind = %s, want MachineConfig", rendered.GetKind())
+	}
+	if rendered.GetName() != "50-virt-vfio-assign" {
+		t.Errorf("Name = %s, want 50-virt-vfio-assign", rendered.GetName())
+	}
+
+	files, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "config", "storage", "files")
+	contents := make(map[string]string)
+	for _, f := range files {
+		file := f.(map[string]interface{})
+		path, _, _ := unstructured.NestedString(file, "path")
+		source, _, _ := unstructured.NestedString(file, "contents", "source")
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(source, "data:text/plain;charset=utf-8;base64,"))
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", path, err)
+		}
+		contents[path] = string(decoded)
+	}
+
+	// Undetected 1002:7408 must not be bound
+	if got := contents["/etc/modprobe.d/vfio.conf"]; got != "options vfio-pci ids=10de:1eb8,10de:2236\n" {
+		t.Errorf("vfio.conf = %q, want detected allowlisted IDs only", got)
+	}
+	if got := contents["/etc/modules-load.d/vfio-pci.conf"]; got != "vfio-pci\n" {
+		t.Errorf("vfio-pci.conf = %q, want vfio-pci", got)
+	}
+}
+
+func TestVFIOAssignNotRenderedWithoutDevices(t *testing.T) {
+	if rendered := renderAssetByName(t, "vfio-assign", vfioRenderContext("1002:7408")); rendered != nil {
+		t.Error("vfio-assign should render empty when no allowlisted device is detected")
+	}
+}
+
+func TestHCOGoldenConfigPermittedHostDevices(t *testing.T) {
+	rendered := renderAssetByName(t, "hco-golden-config", vfioRenderContext("10de:1eb8=nvidia.com/TU104GL_Tesla_T4,10de:2236"))
+
+	devices, found, err := unstructured.NestedSlice(rendered.Object, "spec", "permittedHostDevices", "pciHostDevices")
+	if err != nil || !found {
+		t.Fatalf("pciHostDevices should be present (err: %v)", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("pciHostDevices length = %d, want 2", len(devices))
+	}
+
+	first := devices[0].(map[string]interface{})
+	if first["pciDeviceSelector"] != "10DE:1EB8" || first["resourceName"] != "nvidia.com/TU104GL_Tesla_T4" {
+		t.Errorf("pciHostDevices[0] = %v, want 10DE:1EB8 / nvidia.com/TU104GL_Tesla_T4", first)
+	}
+	second := devices[1].(map[string]interface{})
+	if second["pciDeviceSelector"] != "10DE:2236" || second["resourceName"] != "devices.kubevirt.io/10de_2236" {
+		t.Errorf("pciHostDevices[1] = %v, want 10DE:2236 / devices.kubevirt.io/10de_2236", second)
+	}
+
+	// Without an allowlist the golden config leaves permittedHostDevices to the user
+	rendered = renderAssetByName(t, "hco-golden-config", vfioRenderContext(""))
+	if _, found, _ := unstructured.NestedFieldNoCopy(rendered.Object, "spec", "permittedHostDevices"); found {
+		t.Error("permittedHostDevices should not be rendered without an allowlist")
+	}
+}
+
+func TestHCOGoldenConfigUSBHostDevices(t *testing.T) {
+	renderCtx := vfioRenderContext("")
+	renderCtx.HCO.SetAnnotations(map[string]string{
+		pkgcontext.USBDevicesAnnotation: "0529:0001=kubevirt.io/license-dongle",
+	})
+	renderCtx.Nodes[0].USBDevices = []string{"0529:0001"}
+
+	rendered := renderAssetByName(t, "hco-golden-config", renderCtx)
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(rendered.Object, "spec", "permittedHostDevices", "pciHostDevices"); found {
+		t.Error("pciHostDevices should not be rendered without a vfio-devices allowlist")
+	}
+
+	devices, found, err := unstructured.NestedSlice(rendered.Object, "spec", "permittedHostDevices", "usbHostDevices")
+	if err != nil || !found {
+		t.Fatalf("usbHostDevices should be present (err: %v)", err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("usbHostDevices length = %d, want 1", len(devices))
+	}
+
+	device := devices[0].(map[string]interface{})
+	if device["resourceName"] != "kubevirt.io/license-dongle" {
+		t.Errorf("resourceName = %v, want kubevirt.io/license-dongle", device["resourceName"])
+	}
+	selectors, _, _ := unstructured.NestedSlice(device, "selectors")
+	if len(selectors) != 1 {
+		t.Fatalf("selectors length = %d, want 1", len(selectors))
+	}
+	selector := selectors[0].(map[string]interface{})
+	if selector["vendor"] != "0529" || selector["product"] != "0001" {
+		t.Errorf("selector = %v, want vendor 0529 product 0001", selector)
+	}
+}
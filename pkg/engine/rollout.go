@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MachineConfigRoleLabel selects the MachineConfigPool a MachineConfig is rendered into
+	MachineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+
+	// poolSelectorLabelPrefix is the per-pool label KubeletConfig selectors match on
+	poolSelectorLabelPrefix = "pools.operator.machineconfiguration.openshift.io/"
+
+	// defaultMachineConfigPool is assumed when an object doesn't name its pool
+	defaultMachineConfigPool = "worker"
+)
+
+// MachineConfigPoolGVK is the GroupVersionKind of the OpenShift MachineConfigPool
+var MachineConfigPoolGVK = schema.GroupVersionKind{
+	Group:   "machineconfiguration.openshift.io",
+	Version: "v1",
+	Kind:    "MachineConfigPool",
+}
+
+// NodeMaintenanceGVK is the GroupVersionKind of the Node Maintenance Operator's drain requests
+var NodeMaintenanceGVK = schema.GroupVersionKind{
+	Group:   "nodemaintenance.medik8s.io",
+	Version: "v1beta1",
+	Kind:    "NodeMaintenance",
+}
+
+// PoolState is the rollout state of a MachineConfigPool
+type PoolState string
+
+const (
+	// PoolUpdated means all nodes run the latest rendered config
+	PoolUpdated PoolState = "Updated"
+	// PoolUpdating means a rollout (and rolling reboot) is in progress
+	PoolUpdating PoolState = "Updating"
+	// PoolDegraded means the rollout is stuck and needs an administrator
+	PoolDegraded PoolState = "Degraded"
+	// PoolAbsent means the pool (or the MachineConfigPool CRD) doesn't exist
+	PoolAbsent PoolState = "Absent"
+)
+
+// PoolStatus is the observed rollout state of a MachineConfigPool
+type PoolStatus struct {
+	Name    string
+	State   PoolState
+	Message string // Degraded condition message, if any
+}
+
+// Ready returns true if a node-level change may be applied to the pool now
+func (s PoolStatus) Ready() bool {
+	return s.State == PoolUpdated || s.State == PoolAbsent
+}
+
+// IsNodeLevelKind returns true for kinds whose changes roll out to nodes through
+// the Machine Config Operator, rebooting every node of the target pool
+func IsNodeLevelKind(kind string) bool {
+	return kind == "MachineConfig" || kind == "KubeletConfig"
+}
+
+// TargetPools returns the MachineConfigPools a node-level object rolls out to
+// MachineConfigs name their pool with the role label, KubeletConfigs with the
+// per-pool label in spec.machineConfigPoolSelector. Defaults to "worker".
+func TargetPools(obj *unstructured.Unstructured) []string {
+	if role := obj.GetLabels()[MachineConfigRoleLabel]; role != "" {
+		return []string{role}
+	}
+
+	matchLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "machineConfigPoolSelector", "matchLabels")
+	var pools []string
+	for key := range matchLabels {
+		if pool, ok := strings.CutPrefix(key, poolSelectorLabelPrefix); ok && pool != "" {
+			pools = append(pools, pool)
+		}
+	}
+	if len(pools) == 0 {
+		return []string{defaultMachineConfigPool}
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// NodeRolloutGate holds back node-level changes while a MachineConfigPool rolls out
+//
+// Every MachineConfig or KubeletConfig change makes the Machine Config Operator
+// reboot all nodes of the pool one by one. Applying node-level assets one after
+// the other, each as soon as the previous rollout started, reboots the workers
+// several times in a row. The gate instead looks at the pool once per reconciliation
+// pass: if it is Updated, every pending node-level change of the pass is applied
+// together and rendered into a single rollout; otherwise they all wait.
+type NodeRolloutGate struct {
+	reader client.Reader
+
+	mu     sync.Mutex
+	pools  map[string]PoolStatus // Pool states observed in the current pass
+	drains *int                  // Node Maintenance drains observed in the current pass
+}
+
+// NewNodeRolloutGate creates a gate that reads MachineConfigPools with reader
+// Use an uncached reader: pools are not labeled by the autopilot and thus not cached.
+func NewNodeRolloutGate(reader client.Reader) *NodeRolloutGate {
+	return &NodeRolloutGate{
+		reader: reader,
+		pools:  make(map[string]PoolStatus),
+	}
+}
+
+// BeginPass forgets the pool states of the previous reconciliation pass
+func (g *NodeRolloutGate) BeginPass() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pools = make(map[string]PoolStatus)
+	g.drains = nil
+}
+
+// ActiveDrains returns the number of nodes the Node Maintenance Operator is draining
+// NodeMaintenance requests count until they succeed or fail, and are read once per pass.
+// Nothing is drained without the NodeMaintenance CRD, the operator is a soft dependency.
+func (g *NodeRolloutGate) ActiveDrains(ctx context.Context) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.drains != nil {
+		return *g.drains, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(NodeMaintenanceGVK.GroupVersion().WithKind(NodeMaintenanceGVK.Kind + "List"))
+	if err := g.reader.List(ctx, list); err != nil && !meta.IsNoMatchError(err) {
+		return 0, fmt.Errorf("failed to list NodeMaintenances: %w", err)
+	}
+
+	drains := 0
+	for i := range list.Items {
+		phase, _, _ := unstructured.NestedString(list.Items[i].Object, "status", "phase")
+		if phase != "Succeeded" && phase != "Failed" {
+			drains++
+		}
+	}
+	g.drains = &drains
+	return drains, nil
+}
+
+// Check returns the status of the first target pool of obj that is not ready,
+// or the status of the last one if all are ready
+// Pool states are read once per pass, so all node-level objects of a pass see
+// the state the pool had before the first of them was applied.
+// The second return value lists the pools read for the first time in this pass,
+// so callers can report every pool once per pass.
+func (g *NodeRolloutGate) Check(ctx context.Context, obj *unstructured.Unstructured) (PoolStatus, []PoolStatus, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var status PoolStatus
+	var firstSeen []PoolStatus
+	for _, pool := range TargetPools(obj) {
+		cached, ok := g.pools[pool]
+		if !ok {
+			var err error
+			if cached, err = g.getPoolStatus(ctx, pool); err != nil {
+				return PoolStatus{}, firstSeen, err
+			}
+			g.pools[pool] = cached
+			firstSeen = append(firstSeen, cached)
+		}
+		if status.Name == "" || status.Ready() {
+			status = cached
+		}
+	}
+	return status, firstSeen, nil
+}
+
+// getPoolStatus reads a MachineConfigPool and derives its rollout state
+func (g *NodeRolloutGate) getPoolStatus(ctx context.Context, name string) (PoolStatus, error) {
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(MachineConfigPoolGVK)
+	if err := g.reader.Get(ctx, client.ObjectKey{Name: name}, pool); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// Nothing rolls out to a pool that doesn't exist (yet)
+			return PoolStatus{Name: name, State: PoolAbsent}, nil
+		}
+		return PoolStatus{}, fmt.Errorf("failed to get MachineConfigPool %s: %w", name, err)
+	}
+	return poolStatusOf(pool), nil
+}
+
+// poolStatusOf derives the rollout state from the pool's conditions and rendered configs
+func poolStatusOf(pool *unstructured.Unstructured) PoolStatus {
+	status := PoolStatus{Name: pool.GetName(), State: PoolUpdating}
+
+	conditions, _, _ := unstructured.NestedSlice(pool.Object, "status", "conditions")
+	isTrue := make(map[string]bool)
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _, _ := unstructured.NestedString(condition, "type")
+		condStatus, _, _ := unstructured.NestedString(condition, "status")
+		if condStatus != "True" {
+			continue
+		}
+		isTrue[condType] = true
+		if strings.HasSuffix(condType, "Degraded") && status.Message == "" {
+			status.Message, _, _ = unstructured.NestedString(condition, "message")
+		}
+	}
+
+	// Degraded aggregates NodeDegraded and RenderDegraded, check all in case it lags
+	if isTrue["Degraded"] || isTrue["NodeDegraded"] || isTrue["RenderDegraded"] {
+		status.State = PoolDegraded
+		return status
+	}
+
+	// A new rendered config that isn't rolled out yet is a pending update
+	specConfig, _, _ := unstructured.NestedString(pool.Object, "spec", "configuration", "name")
+	statusConfig, _, _ := unstructured.NestedString(pool.Object, "status", "configuration", "name")
+	if isTrue["Updated"] && !isTrue["Updating"] && specConfig == statusConfig {
+		status.State = PoolUpdated
+	}
+	return status
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func newTestPool(name, specConfig, statusConfig string, trueConditions ...string) *unstructured.Unstructured {
+	conditions := []interface{}{}
+	for _, condType := range []string{"Updated", "Updating", "Degraded"} {
+		status := "False"
+		for _, t := range trueConditions {
+			if t == condType {
+				status = "True"
+			}
+		}
+		condition := map[string]interface{}{"type": condType, "status": status}
+		if condType == "Degraded" && status == "True" {
+			condition["message"] = "Node worker-0 is reporting: failed to drain"
+		}
+		conditions = append(conditions, condition)
+	}
+
+	pool := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"configuration": map[string]interface{}{"name": specConfig}},
+		"status": map[string]interface{}{"configuration": map[string]interface{}{"name": statusConfig}, "conditions": conditions},
+	}}
+	pool.SetGroupVersionKind(MachineConfigPoolGVK)
+	pool.SetName(name)
+	return pool
+}
+
+func TestTargetPools(t *testing.T) {
+	machineConfig := &unstructured.Unstructured{}
+	machineConfig.SetKind("MachineConfig")
+	machineConfig.SetLabels(map[string]string{MachineConfigRoleLabel: "virt-intel"})
+
+	kubeletConfig := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "KubeletConfig",
+		"spec": map[string]interface{}{
+			"machineConfigPoolSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					"pools.operator.machineconfiguration.openshift.io/worker": "",
+					"pools.operator.machineconfiguration.openshift.io/infra":  "",
+					"custom": "label",
+				},
+			},
+		},
+	}}
+
+	unlabeled := &unstructured.Unstructured{}
+	unlabeled.SetKind("MachineConfig")
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want []string
+	}{
+		{"MachineConfig role label", machineConfig, []string{"virt-intel"}},
+		{"KubeletConfig pool selector", kubeletConfig, []string{"infra", "worker"}},
+		{"default pool", unlabeled, []string{"worker"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TargetPools(tt.obj); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TargetPools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolStatusOf(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *unstructured.Unstructured
+		want PoolState
+	}{
+		{"updated", newTestPool("worker", "rendered-1", "rendered-1", "Updated"), PoolUpdated},
+		{"updating", newTestPool("worker", "rendered-2", "rendered-1", "Updating"), PoolUpdating},
+		{"new rendered config not picked up yet", newTestPool("worker", "rendered-2", "rendered-1", "Updated"), PoolUpdating},
+		{"degraded", newTestPool("worker", "rendered-2", "rendered-1", "Updating", "Degraded"), PoolDegraded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := poolStatusOf(tt.pool); got.State != tt.want {
+				t.Errorf("poolStatusOf() = %s, want %s", got.State, tt.want)
+			}
+		})
+	}
+
+	degraded := poolStatusOf(newTestPool("worker", "rendered-1", "rendered-1", "Degraded"))
+	if degraded.Message != "Node worker-0 is reporting: failed to drain" {
+		t.Errorf("degraded message = %q", degraded.Message)
+	}
+}
+
+func TestNodeRolloutGate(t *testing.T) {
+	ctx := t.Context()
+	pool := newTestPool("worker", "rendered-1", "rendered-1", "Updated")
+	fakeClient := fake.NewClientBuilder().WithObjects(pool).Build()
+	gate := NewNodeRolloutGate(fakeClient)
+
+	machineConfig := &unstructured.Unstructured{}
+	machineConfig.SetKind("MachineConfig")
+	machineConfig.SetLabels(map[string]string{MachineConfigRoleLabel: "worker"})
+
+	gate.BeginPass()
+	status, firstSeen, err := gate.Check(ctx, machineConfig)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !status.Ready() || len(firstSeen) != 1 {
+		t.Fatalf("Check() = %+v, firstSeen=%v, want ready on first look", status, firstSeen)
+	}
+
+	// The pool starts rolling out our first change; the rest of the pass is batched with it
+	updating := newTestPool("worker", "rendered-2", "rendered-1", "Updating")
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(MachineConfigPoolGVK)
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "worker"}, live); err != nil {
+		t.Fatal(err)
+	}
+	updating.SetResourceVersion(live.GetResourceVersion())
+	if err := fakeClient.Update(ctx, updating); err != nil {
+		t.Fatal(err)
+	}
+	if status, firstSeen, _ = gate.Check(ctx, machineConfig); !status.Ready() || len(firstSeen) != 0 {
+		t.Errorf("Check() later in the same pass = %+v, firstSeen=%v, want cached ready state", status, firstSeen)
+	}
+
+	// The next pass waits for the rollout
+	gate.BeginPass()
+	if status, _, _ = gate.Check(ctx, machineConfig); status.Ready() || status.State != PoolUpdating {
+		t.Errorf("Check() in the next pass = %+v, want updating", status)
+	}
+
+	// Pools that don't exist don't block
+	custom := &unstructured.Unstructured{}
+	custom.SetKind("MachineConfig")
+	custom.SetLabels(map[string]string{MachineConfigRoleLabel: "virt-amd"})
+	if status, _, err = gate.Check(ctx, custom); err != nil || status.State != PoolAbsent || !status.Ready() {
+		t.Errorf("Check() for missing pool = %+v, %v, want absent and ready", status, err)
+	}
+}
+
+func TestNodeRolloutGateReadsEveryTargetPool(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		newTestPool("virt-a", "rendered-1", "rendered-1", "Degraded"),
+		newTestPool("virt-b", "rendered-1", "rendered-1", "Updated"),
+	).Build()
+	gate := NewNodeRolloutGate(fakeClient)
+
+	kubeletConfig := &unstructured.Unstructured{}
+	kubeletConfig.SetKind("KubeletConfig")
+	_ = unstructured.SetNestedStringMap(kubeletConfig.Object, map[string]string{
+		poolSelectorLabelPrefix + "virt-a": "",
+		poolSelectorLabelPrefix + "virt-b": "",
+	}, "spec", "machineConfigPoolSelector", "matchLabels")
+
+	gate.BeginPass()
+	status, firstSeen, err := gate.Check(t.Context(), kubeletConfig)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if status.Name != "virt-a" || status.State != PoolDegraded {
+		t.Errorf("Check() = %+v, want the degraded pool", status)
+	}
+	// A pool behind a degraded one is still read, so its metric is kept current
+	if len(firstSeen) != 2 || firstSeen[1].Name != "virt-b" || firstSeen[1].State != PoolUpdated {
+		t.Errorf("Check() firstSeen = %+v, want both pools", firstSeen)
+	}
+}
+
+func TestObserveNodePoolsClearsDegradedPool(t *testing.T) {
+	observability.MachineConfigPoolDegraded.Reset()
+	machineConfig := &unstructured.Unstructured{}
+	machineConfig.SetKind("MachineConfig")
+	machineConfig.SetLabels(map[string]string{MachineConfigRoleLabel: "worker"})
+	renderCtx := &pkgcontext.RenderContext{}
+
+	for _, tt := range []struct {
+		pool *unstructured.Unstructured
+		want float64
+	}{
+		{newTestPool("worker", "rendered-1", "rendered-1", "Degraded"), 1},
+		// Recovered while nothing is pending: the next pass still reports it
+		{newTestPool("worker", "rendered-1", "rendered-1", "Updated"), 0},
+	} {
+		p := NewPatcher(fake.NewClientBuilder().Build(), fake.NewClientBuilder().WithObjects(tt.pool).Build(), assets.NewLoader())
+		p.BeginPass()
+		if _, err := p.observeNodePools(t.Context(), machineConfig, renderCtx); err != nil {
+			t.Fatalf("observeNodePools() error = %v", err)
+		}
+		if got := testutil.ToFloat64(observability.MachineConfigPoolDegraded.WithLabelValues("worker")); got != tt.want {
+			t.Errorf("machine_config_pool_degraded = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func newTestNodeMaintenance(name, phase string) *unstructured.Unstructured {
+	nm := &unstructured.Unstructured{}
+	nm.SetGroupVersionKind(NodeMaintenanceGVK)
+	nm.SetName(name)
+	_ = unstructured.SetNestedField(nm.Object, name, "spec", "nodeName")
+	if phase != "" {
+		_ = unstructured.SetNestedField(nm.Object, phase, "status", "phase")
+	}
+	return nm
+}
+
+func TestCheckNodeMaintenance(t *testing.T) {
+	machineConfig := &unstructured.Unstructured{}
+	machineConfig.SetKind("MachineConfig")
+	machineConfig.SetName("50-virt-pci-passthrough")
+
+	withLimit := func(limit int64) *unstructured.Unstructured {
+		hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+		_ = unstructured.SetNestedField(hco.Object, limit, "spec", "liveMigrationConfig", "parallelOutboundMigrationsPerNode")
+		return hco
+	}
+
+	tests := []struct {
+		name        string
+		maintenance []client.Object
+		hco         *unstructured.Unstructured
+		want        bool
+	}{
+		{"no drains", nil, withLimit(2), true},
+		{"finished drains don't count", []client.Object{
+			newTestNodeMaintenance("worker-0", "Succeeded"),
+			newTestNodeMaintenance("worker-1", "Failed"),
+		}, withLimit(2), true},
+		{"drains below the limit", []client.Object{newTestNodeMaintenance("worker-0", "Running")}, withLimit(2), true},
+		{"drains reach the limit", []client.Object{
+			newTestNodeMaintenance("worker-0", "Running"),
+			newTestNodeMaintenance("worker-1", ""),
+		}, withLimit(2), false},
+		{"lower HCO limit", []client.Object{newTestNodeMaintenance("worker-0", "Running")}, withLimit(1), false},
+		{"KubeVirt default without limit", []client.Object{newTestNodeMaintenance("worker-0", "Running")},
+			pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := fake.NewClientBuilder().WithObjects(tt.maintenance...).Build()
+			p := NewPatcher(fake.NewClientBuilder().Build(), reader, assets.NewLoader())
+			p.BeginPass()
+
+			ready, err := p.checkNodeMaintenance(t.Context(), machineConfig, &pkgcontext.RenderContext{HCO: tt.hco})
+			if err != nil {
+				t.Fatalf("checkNodeMaintenance() error = %v", err)
+			}
+			if ready != tt.want {
+				t.Errorf("checkNodeMaintenance() = %v, want %v", ready, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+const (
+	// ThrottleCheckpointName is the name of the Lease holding the anti-thrashing checkpoint
+	ThrottleCheckpointName = "virt-platform-autopilot-throttling"
+
+	// ThrottleCheckpointAnnotation is the Lease annotation holding the JSON checkpoint
+	ThrottleCheckpointAnnotation = "platform.kubevirt.io/throttling-state"
+)
+
+// leaseGVK is the GVK of the checkpoint object
+// A Lease is used because the autopilot already has RBAC for it (leader election).
+var leaseGVK = schema.GroupVersionKind{Group: "coordination.k8s.io", Version: "v1", Kind: "Lease"}
+
+// ThrottleCheckpoint is the persisted token bucket and thrashing detector state
+type ThrottleCheckpoint struct {
+	Buckets   map[string]throttling.BucketSnapshot    `json:"buckets,omitempty"`
+	Thrashing map[string]throttling.ThrashingSnapshot `json:"thrashing,omitempty"`
+}
+
+// ThrottleCheckpointer persists the anti-thrashing state in an annotation of a Lease owned by the autopilot,
+// so edit-war history survives restarts (including the self-restart on CRD changes) and leader changes.
+type ThrottleCheckpointer struct {
+	applier   *Applier
+	namespace string
+	lastSaved string
+}
+
+// NewThrottleCheckpointer creates a checkpointer storing its Lease in namespace
+func NewThrottleCheckpointer(c client.Client, apiReader client.Reader, namespace string) *ThrottleCheckpointer {
+	return &ThrottleCheckpointer{
+		applier:   NewApplier(c, apiReader),
+		namespace: namespace,
+	}
+}
+
+// Load reads the checkpoint, bypassing the cache
+// Returns an empty checkpoint if none was saved yet. A corrupt checkpoint is logged and
+// treated as empty, so the next Save overwrites it instead of blocking persistence forever.
+func (c *ThrottleCheckpointer) Load(ctx context.Context) (*ThrottleCheckpoint, error) {
+	lease := &unstructured.Unstructured{}
+	lease.SetGroupVersionKind(leaseGVK)
+	key := client.ObjectKey{Namespace: c.namespace, Name: ThrottleCheckpointName}
+	if err := c.applier.GetDirect(ctx, key, lease); err != nil {
+		if errors.IsNotFound(err) {
+			return &ThrottleCheckpoint{}, nil
+		}
+		return nil, fmt.Errorf("failed to get throttling checkpoint: %w", err)
+	}
+
+	raw := lease.GetAnnotations()[ThrottleCheckpointAnnotation]
+	checkpoint := &ThrottleCheckpoint{}
+	if raw == "" {
+		return checkpoint, nil
+	}
+	if err := json.Unmarshal([]byte(raw), checkpoint); err != nil {
+		log.FromContext(ctx).Error(err, "Discarding corrupt throttling checkpoint",
+			"namespace", c.namespace,
+			"name", ThrottleCheckpointName,
+		)
+		return &ThrottleCheckpoint{}, nil
+	}
+	c.lastSaved = raw
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint, skipping the write if it didn't change since the last Load or Save
+func (c *ThrottleCheckpointer) Save(ctx context.Context, checkpoint *ThrottleCheckpoint) error {
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode throttling checkpoint: %w", err)
+	}
+	if string(raw) == c.lastSaved {
+		return nil
+	}
+
+	lease := &unstructured.Unstructured{}
+	lease.SetGroupVersionKind(leaseGVK)
+	lease.SetNamespace(c.namespace)
+	lease.SetName(ThrottleCheckpointName)
+	lease.SetAnnotations(map[string]string{ThrottleCheckpointAnnotation: string(raw)})
+
+	if _, err := c.applier.Apply(ctx, lease, true); err != nil {
+		return fmt.Errorf("failed to save throttling checkpoint: %w", err)
+	}
+	c.lastSaved = string(raw)
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+func TestThrottleCheckpointerRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := t.Context()
+
+	checkpointer := NewThrottleCheckpointer(fakeClient, nil, "openshift-cnv")
+	empty, err := checkpointer.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() without checkpoint error = %v", err)
+	}
+	if len(empty.Buckets) != 0 || len(empty.Thrashing) != 0 {
+		t.Errorf("Load() without checkpoint = %+v, want empty", empty)
+	}
+
+	lastFill := time.Now().Truncate(time.Second)
+	saved := &ThrottleCheckpoint{
+		Buckets:   map[string]throttling.BucketSnapshot{"ns/cm/ConfigMap": {Tokens: 1, LastFill: lastFill}},
+		Thrashing: map[string]throttling.ThrashingSnapshot{"ns/cm/ConfigMap": {ConsecutiveThrottles: 2}},
+	}
+	if err := checkpointer.Save(ctx, saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	lease := &coordinationv1.Lease{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: ThrottleCheckpointName}, lease); err != nil {
+		t.Fatalf("checkpoint Lease not created: %v", err)
+	}
+	if lease.Labels[ManagedByLabel] != ManagedByValue {
+		t.Errorf("checkpoint Lease labels = %v, want managed-by label", lease.Labels)
+	}
+
+	// A new checkpointer, as after a restart, sees the saved state
+	loaded, err := NewThrottleCheckpointer(fakeClient, nil, "openshift-cnv").Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	bucket := loaded.Buckets["ns/cm/ConfigMap"]
+	if bucket.Tokens != 1 || !bucket.LastFill.Equal(lastFill) {
+		t.Errorf("Load() bucket = %+v, want tokens 1 filled at %v", bucket, lastFill)
+	}
+	if loaded.Thrashing["ns/cm/ConfigMap"].ConsecutiveThrottles != 2 {
+		t.Errorf("Load() thrashing = %+v", loaded.Thrashing)
+	}
+}
+
+func TestThrottleCheckpointCorrupt(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := &coordinationv1.Lease{}
+	corrupt.Namespace = "openshift-cnv"
+	corrupt.Name = ThrottleCheckpointName
+	corrupt.Annotations = map[string]string{ThrottleCheckpointAnnotation: `{"buckets":`}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(corrupt).Build()
+	ctx := t.Context()
+
+	checkpoint, err := NewThrottleCheckpointer(fakeClient, nil, "openshift-cnv").Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() of a corrupt checkpoint error = %v, want it treated as empty", err)
+	}
+	if len(checkpoint.Buckets) != 0 || len(checkpoint.Thrashing) != 0 {
+		t.Errorf("Load() of a corrupt checkpoint = %+v, want empty", checkpoint)
+	}
+
+	// The patcher counts the state as restored and overwrites the corrupt checkpoint
+	p := NewPatcher(fakeClient, nil, assets.NewLoader())
+	p.SetThrottleCheckpointer(NewThrottleCheckpointer(fakeClient, nil, "openshift-cnv"))
+	p.RestoreThrottleState(ctx)
+	if !p.restored {
+		t.Fatal("RestoreThrottleState() did not restore from a corrupt checkpoint")
+	}
+	p.SaveThrottleState(ctx)
+
+	lease := &coordinationv1.Lease{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: ThrottleCheckpointName}, lease); err != nil {
+		t.Fatalf("Failed to get checkpoint Lease: %v", err)
+	}
+	if !json.Valid([]byte(lease.Annotations[ThrottleCheckpointAnnotation])) {
+		t.Errorf("checkpoint = %q, want it overwritten with valid JSON", lease.Annotations[ThrottleCheckpointAnnotation])
+	}
+}
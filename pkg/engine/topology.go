@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const (
+	// defaultReservedSystemCPUs is used when no node topology is known (e.g. offline rendering)
+	defaultReservedSystemCPUs = "0-1"
+
+	// defaultSystemReservedMemoryGi is used when no node memory capacity is known
+	defaultSystemReservedMemoryGi = 4
+
+	// evictionHardMemoryMi is the kubelet default memory.available hard eviction threshold
+	// The Memory Manager requires reservedMemory to cover it on top of system-reserved memory
+	evictionHardMemoryMi = 100
+
+	// coresPerReservedCore is the number of physical cores served by one reserved core
+	coresPerReservedCore = 32
+
+	// minReservedCores and maxReservedCores bound the housekeeping reservation
+	minReservedCores = 2
+	maxReservedCores = 8
+
+	// topologyPoolPrefix prefixes the custom MachineConfigPools of topology groups
+	topologyPoolPrefix = "virt-"
+
+	// cpuManagerFeatureGate enables the kubelet-cpu-manager asset, which renders the topology pools
+	cpuManagerFeatureGate = "CPUManager"
+)
+
+// systemReservedMemoryTiers mirrors the OpenShift autoSizingReserved memory formula, in
+// hundredths of the GiB in each tier: 25% of the first 4GiB, 20% of the next 4GiB,
+// 10% of the next 8GiB, 6% of the next 112GiB and 2% of anything above 128GiB
+var systemReservedMemoryTiers = []struct {
+	upToGi     int64
+	hundredths int64
+}{
+	{4, 25},
+	{8, 20},
+	{16, 10},
+	{128, 6},
+	{math.MaxInt64, 2},
+}
+
+// TopologyGroup is a set of nodes sharing the kubelet reservations derived from their topology
+// Sibling thread IDs depend on the core count of a node, and the Memory Manager requires
+// reservedMemory to match the system-reserved memory auto-sized for each node, so nodes of
+// different sizes can't share a KubeletConfig.
+type TopologyGroup struct {
+	// Pool is the custom MachineConfigPool of the group, named after its topology
+	// Empty when the worker pool itself is configured (see topologyGroups).
+	Pool string
+
+	// Vendor is the CPU vendor of the nodes in the group, empty if unknown
+	// Its pool also selects the virt-<vendor> MachineConfigs of the pci-passthrough asset.
+	Vendor string
+
+	// Nodes are the sorted names of the nodes in the group
+	Nodes []string
+
+	// ReservedSystemCPUs is the housekeeping cpuset: whole cores plus their sibling threads
+	ReservedSystemCPUs string
+
+	// ReservedMemory is the Memory Manager reservation: system-reserved plus hard eviction memory
+	ReservedMemory string
+}
+
+// TopologyGroups are the topology groups of the worker nodes
+type TopologyGroups []TopologyGroup
+
+// Pools returns the names of the custom pools of the groups
+// Usage: {{ if not (topologyGroups .).Pools }}...{{ end }}
+func (g TopologyGroups) Pools() []string {
+	var pools []string
+	for i := range g {
+		if g[i].Pool != "" {
+			pools = append(pools, g[i].Pool)
+		}
+	}
+	return pools
+}
+
+// topologyKey identifies the nodes a TopologyGroup is computed for
+type topologyKey struct {
+	vendor  string
+	cores   int64
+	threads int64
+	memGi   int64
+}
+
+// topologyGroups splits the worker nodes into groups sharing the same reservations
+// Every group gets a custom pool, rendered by the kubelet-cpu-manager asset, so the pools only
+// exist while the CPUManager feature gate enables that asset. Pools are named after the
+// topology they stand for, so a node only changes pool when its own topology changes.
+// Without pools the worker pool is configured: with the reservations of the workers if they
+// share a topology, else (or without node data, e.g. offline) with the 0-1 and 4Gi defaults.
+// Usage: {{ range topologyGroups . }}...{{ end }}
+func topologyGroups(ctx *pkgcontext.RenderContext) TopologyGroups {
+	groups := groupByTopology(ctx.NodesWithRole("worker"))
+	if ctx.HCO != nil && ctx.FeatureGateEnabled(cpuManagerFeatureGate) {
+		return groups
+	}
+
+	if len(groups) > 1 {
+		return TopologyGroups{defaultTopologyGroup()}
+	}
+	groups[0].Pool = ""
+	return groups
+}
+
+// groupByTopology groups nodes by CPU vendor, core count, threads per core and whole GiB of memory
+// Nodes of unknown capacity are left out when others are known. Without any node data a single
+// group without pool falls back to the defaults.
+func groupByTopology(nodes []pkgcontext.NodeInfo) TopologyGroups {
+	groups := make(map[topologyKey]*TopologyGroup)
+	var keys []topologyKey
+	for i := range nodes {
+		node := &nodes[i]
+		if node.CPUs <= 0 || node.Memory <= 0 {
+			continue
+		}
+
+		threads := max(int64(node.ThreadsPerCore), 1)
+		key := topologyKey{vendor: node.CPUVendor, cores: node.CPUs / threads, threads: threads, memGi: node.Memory >> 30}
+		group, exists := groups[key]
+		if !exists {
+			group = &TopologyGroup{
+				Pool:               topologyPoolName(key),
+				Vendor:             key.vendor,
+				ReservedSystemCPUs: reservedSystemCPUs(key.cores, key.threads),
+				ReservedMemory:     reservedMemory(key.memGi),
+			}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.Nodes = append(group.Nodes, node.Name)
+	}
+
+	if len(keys) == 0 {
+		return TopologyGroups{defaultTopologyGroup()}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.vendor != b.vendor {
+			return a.vendor < b.vendor
+		}
+		if a.cores != b.cores {
+			return a.cores < b.cores
+		}
+		if a.threads != b.threads {
+			return a.threads < b.threads
+		}
+		return a.memGi < b.memGi
+	})
+
+	result := make(TopologyGroups, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		sort.Strings(group.Nodes)
+		result = append(result, *group)
+	}
+	return result
+}
+
+// defaultTopologyGroup is the group of the worker pool when no node topology applies
+func defaultTopologyGroup() TopologyGroup {
+	return TopologyGroup{
+		ReservedSystemCPUs: defaultReservedSystemCPUs,
+		ReservedMemory:     reservedMemory(defaultSystemReservedMemoryGi),
+	}
+}
+
+// topologyPoolName names the pool of a topology group after the topology it stands for
+// e.g. virt-intel-32c2t-256gi, or virt-32c2t-256gi when the CPU vendor is unknown
+func topologyPoolName(key topologyKey) string {
+	name := topologyPoolPrefix
+	if key.vendor != "" {
+		name += key.vendor + "-"
+	}
+	return name + fmt.Sprintf("%dc%dt-%dgi", key.cores, key.threads, key.memGi)
+}
+
+// reservedSystemCPUs computes the housekeeping cpuset of a node topology
+// Whole physical cores are reserved from the start of the CPU list, together with their
+// sibling threads (Linux enumerates sibling N+i for core i on x86 servers), so the
+// result stays compatible with the full-pcpus-only CPU Manager option.
+func reservedSystemCPUs(cores, threads int64) string {
+	reserved := (cores + coresPerReservedCore - 1) / coresPerReservedCore
+	reserved = max(reserved, minReservedCores)
+	reserved = min(reserved, maxReservedCores, cores)
+
+	var ranges []string
+	for t := int64(0); t < threads; t++ {
+		first := t * cores
+		ranges = append(ranges, formatCPURange(first, first+reserved-1))
+	}
+	return strings.Join(ranges, ",")
+}
+
+// reservedMemory computes the Memory Manager reservation of a node memory size
+// The kubelet requires it to equal system-reserved plus the hard eviction threshold exactly,
+// so the value is kept in the decimal form of the auto-sized system-reserved memory.
+func reservedMemory(memGi int64) string {
+	// In hundredths of MiB, exact for hundredths of GiB
+	total := autoSizedSystemReservedHundredthsGi(memGi)*1024 + evictionHardMemoryMi*100
+	value := fmt.Sprintf("%d.%02d", total/100, total%100)
+	return strings.TrimSuffix(strings.TrimRight(value, "0"), ".") + "Mi"
+}
+
+// autoSizedSystemReservedHundredthsGi returns the system-reserved memory in hundredths of GiB
+// The node sizing script reads the memory in whole GiB and reserves a decimal amount of GiB.
+func autoSizedSystemReservedHundredthsGi(memGi int64) int64 {
+	var reserved, lower int64
+	for _, tier := range systemReservedMemoryTiers {
+		if memGi <= lower {
+			break
+		}
+		reserved += (min(memGi, tier.upToGi) - lower) * tier.hundredths
+		lower = tier.upToGi
+	}
+	return reserved
+}
+
+// formatCPURange formats an inclusive CPU range in cpuset notation
+func formatCPURange(first, last int64) string {
+	if first == last {
+		return fmt.Sprintf("%d", first)
+	}
+	return fmt.Sprintf("%d-%d", first, last)
+}
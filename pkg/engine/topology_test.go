@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const gi = int64(1) << 30
+
+func TestReservedSystemCPUs(t *testing.T) {
+	tests := []struct {
+		name           string
+		cores, threads int64
+		want           string
+	}{
+		{"32 cores without SMT", 32, 1, "0-1"},
+		{"32 cores with SMT", 32, 2, "0-1,32-33"},
+		{"52 cores with SMT", 52, 2, "0-1,52-53"},
+		{"128 cores with SMT", 128, 2, "0-3,128-131"},
+		{"256 cores with SMT capped", 256, 2, "0-7,256-263"},
+		{"tiny node", 1, 1, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reservedSystemCPUs(tt.cores, tt.threads); got != tt.want {
+				t.Errorf("reservedSystemCPUs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReservedMemory(t *testing.T) {
+	tests := []struct {
+		name  string
+		memGi int64
+		want  string
+	}{
+		// System-reserved memory as computed by the node sizing script, plus 100Mi
+		{"4GiB", 4, "1124Mi"},         // 1Gi
+		{"16GiB", 16, "2762.4Mi"},     // 2.6Gi
+		{"128GiB", 128, "9643.68Mi"},  // 9.32Gi
+		{"1TiB", 1024, "27993.76Mi"},  // 27.24Gi
+		{"3GiB", 3, "868Mi"},          // 0.75Gi
+		{"255GiB", 255, "12244.64Mi"}, // 11.86Gi
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reservedMemory(tt.memGi); got != tt.want {
+				t.Errorf("reservedMemory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupByTopology(t *testing.T) {
+	t.Run("no node data", func(t *testing.T) {
+		groups := groupByTopology(nil)
+		if len(groups) != 1 || groups[0].Pool != "" {
+			t.Fatalf("groupByTopology() = %+v, want a single group without pool", groups)
+		}
+		if groups[0].ReservedSystemCPUs != "0-1" || groups[0].ReservedMemory != "1124Mi" {
+			t.Errorf("defaults = %s/%s, want 0-1/1124Mi", groups[0].ReservedSystemCPUs, groups[0].ReservedMemory)
+		}
+	})
+
+	t.Run("homogeneous nodes share the pool", func(t *testing.T) {
+		// Capacity differing below a GiB doesn't change the auto-sized reservation
+		groups := groupByTopology([]pkgcontext.NodeInfo{
+			{Name: "worker-1", CPUs: 64, ThreadsPerCore: 2, Memory: 256*gi - 300<<20},
+			{Name: "worker-0", CPUs: 64, ThreadsPerCore: 2, Memory: 256*gi - 200<<20},
+		})
+		if len(groups) != 1 || groups[0].Pool != "virt-32c2t-255gi" {
+			t.Fatalf("groupByTopology() = %+v, want a single virt-32c2t-255gi group", groups)
+		}
+		if got := groups[0].Nodes; len(got) != 2 || got[0] != "worker-0" {
+			t.Errorf("Nodes = %v, want sorted worker-0, worker-1", got)
+		}
+		if groups[0].ReservedSystemCPUs != "0-1,32-33" {
+			t.Errorf("ReservedSystemCPUs = %s, want 0-1,32-33", groups[0].ReservedSystemCPUs)
+		}
+	})
+
+	t.Run("heterogeneous nodes get a pool each", func(t *testing.T) {
+		groups := groupByTopology([]pkgcontext.NodeInfo{
+			{Name: "big-0", CPUs: 256, ThreadsPerCore: 2, Memory: 1024 * gi},
+			{Name: "small-0", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi},
+			{Name: "small-1", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi},
+			{Name: "unknown-0"},
+		})
+		if len(groups) != 2 {
+			t.Fatalf("groupByTopology() = %+v, want 2 groups", groups)
+		}
+
+		small, big := groups[0], groups[1]
+		if small.Pool != "virt-32c2t-128gi" || len(small.Nodes) != 2 {
+			t.Errorf("small group = %+v, want pool virt-32c2t-128gi with 2 nodes", small)
+		}
+		if small.ReservedSystemCPUs != "0-1,32-33" || small.ReservedMemory != "9643.68Mi" {
+			t.Errorf("small group reservations = %s/%s", small.ReservedSystemCPUs, small.ReservedMemory)
+		}
+		if big.Pool != "virt-128c2t-1024gi" || len(big.Nodes) != 1 {
+			t.Errorf("big group = %+v, want pool virt-128c2t-1024gi with 1 node", big)
+		}
+		if big.ReservedSystemCPUs != "0-3,128-131" || big.ReservedMemory != "27993.76Mi" {
+			t.Errorf("big group reservations = %s/%s", big.ReservedSystemCPUs, big.ReservedMemory)
+		}
+	})
+
+	t.Run("pools don't depend on other nodes", func(t *testing.T) {
+		// A node of a new size or vendor gets a new pool, the others keep theirs
+		before := groupByTopology([]pkgcontext.NodeInfo{
+			{Name: "worker-0", CPUVendor: "intel", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi},
+		})
+		after := groupByTopology([]pkgcontext.NodeInfo{
+			{Name: "worker-0", CPUVendor: "intel", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi},
+			{Name: "worker-1", CPUVendor: "amd", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi},
+			{Name: "worker-2", CPUVendor: "intel", CPUs: 256, ThreadsPerCore: 2, Memory: 1024 * gi},
+		})
+		if before[0].Pool != "virt-intel-32c2t-128gi" {
+			t.Fatalf("worker-0 pool = %s, want virt-intel-32c2t-128gi", before[0].Pool)
+		}
+		var pools []string
+		for _, group := range after {
+			pools = append(pools, group.Pool)
+			if group.Pool == before[0].Pool && !reflect.DeepEqual(group.Nodes, before[0].Nodes) {
+				t.Errorf("%s nodes = %v, want %v", group.Pool, group.Nodes, before[0].Nodes)
+			}
+		}
+		want := []string{"virt-amd-32c2t-128gi", "virt-intel-32c2t-128gi", "virt-intel-128c2t-1024gi"}
+		if !reflect.DeepEqual(pools, want) {
+			t.Errorf("pools = %v, want %v", pools, want)
+		}
+	})
+}
+
+func TestTopologyGroups(t *testing.T) {
+	newContext := func(featureGates []string, nodes ...pkgcontext.NodeInfo) *pkgcontext.RenderContext {
+		hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+		if err := unstructured.SetNestedStringSlice(hco.Object, featureGates, "spec", "featureGates"); err != nil {
+			t.Fatalf("Failed to set feature gates: %v", err)
+		}
+		for i := range nodes {
+			nodes[i].Roles = []string{"worker"}
+		}
+		return &pkgcontext.RenderContext{HCO: hco, Nodes: nodes}
+	}
+	small := pkgcontext.NodeInfo{Name: "small-0", CPUs: 64, ThreadsPerCore: 2, Memory: 128 * gi}
+	big := pkgcontext.NodeInfo{Name: "big-0", CPUs: 256, ThreadsPerCore: 2, Memory: 1024 * gi}
+
+	t.Run("CPU Manager renders a pool per topology", func(t *testing.T) {
+		groups := topologyGroups(newContext([]string{"CPUManager"}, small))
+		if pools := groups.Pools(); len(pools) != 1 || pools[0] != "virt-32c2t-128gi" {
+			t.Errorf("Pools() = %v, want [virt-32c2t-128gi]", pools)
+		}
+	})
+
+	t.Run("without CPU Manager the worker pool is configured", func(t *testing.T) {
+		groups := topologyGroups(newContext(nil, small))
+		if len(groups) != 1 || groups.Pools() != nil || groups[0].ReservedSystemCPUs != "0-1,32-33" {
+			t.Errorf("topologyGroups() = %+v, want the small node reservations without pool", groups)
+		}
+
+		groups = topologyGroups(newContext(nil, small, big))
+		if len(groups) != 1 || groups.Pools() != nil || groups[0].ReservedSystemCPUs != "0-1" {
+			t.Errorf("topologyGroups() = %+v, want the defaults without pool", groups)
+		}
+	})
+}
@@ -43,7 +43,7 @@ func TestMetricLabelConsistency(t *testing.T) {
 		{
 			name:           "ThrashingTotal has correct labels",
 			metric:         ThrashingTotal,
-			expectedLabels: []string{"kind", "name", "namespace"},
+			expectedLabels: []string{"kind", "name", "namespace", "manager"},
 			setupFunc:      setupThrashingMetric,
 		},
 		{
@@ -154,7 +154,7 @@ func setupThrashingMetric() {
 	obj.SetKind("TestKind")
 	obj.SetName("test-name")
 	obj.SetNamespace("test-ns")
-	IncThrashing(obj)
+	IncThrashing(obj, "test-manager")
 }
 
 func setupCustomizationMetric() {
@@ -325,6 +325,7 @@ func TestAllMetricsHaveSubsystem(t *testing.T) {
 		CustomizationInfo,
 		MissingDependency,
 		ReconcileDuration,
+		ReconcilePassDuration,
 	}
 
 	expectedSubsystem := "virt_platform"
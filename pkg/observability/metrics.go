@@ -45,17 +45,19 @@ var (
 	// ThrashingTotal counts reconciliation throttling events (token bucket exhaustion).
 	// Increments when the "Reconcile Gate" is hit (update budget exhausted).
 	// Indicates an active "Edit War" between the autopilot and external changes.
+	// The manager label names the competing field manager ("unknown" if it couldn't be identified).
 	ThrashingTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Subsystem: subsystem,
 			Name:      "thrashing_total",
 			Help:      "Total number of reconciliation throttling events (anti-thrashing gate hits)",
 		},
-		[]string{"kind", "name", "namespace"},
+		[]string{"kind", "name", "namespace", "manager"},
 	)
 
 	// PausedResources tracks resources currently paused due to edit wars.
 	// 1 = paused (reconcile-paused annotation set), 0 = active (annotation removed)
+	// The pause label tells an automatic resume ("auto-resume") from a permanent pause ("permanent").
 	// This gauge provides a stable signal for alerting on ongoing edit wars.
 	PausedResources = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -63,7 +65,7 @@ var (
 			Name:      "paused_resources",
 			Help:      "Resources currently paused due to edit war detection (1=paused, 0=active)",
 		},
-		[]string{"kind", "name", "namespace"},
+		[]string{"kind", "name", "namespace", "pause"},
 	)
 
 	// CustomizationInfo tracks intentional deviations from the Golden State.
@@ -103,6 +105,18 @@ var (
 		[]string{"kind", "name", "namespace"},
 	)
 
+	// ReconcilePassDuration tracks how long a whole reconciliation pass takes.
+	// Covers all assets, reconciled level by level with bounded concurrency.
+	// Growth with the catalog size implies the asset concurrency is too low.
+	ReconcilePassDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "reconcile_pass_duration_seconds",
+			Help:      "Duration of a whole reconciliation pass over all assets",
+			Buckets:   []float64{0.1, 0.5, 1.0, 2.5, 5.0, 10.0, 30.0, 60.0, 120.0},
+		},
+	)
+
 	// TombstoneStatus tracks tombstone deletion status.
 	// 1 = exists (not yet deleted), 0 = deleted, -1 = error, -2 = skipped (label mismatch)
 	// Used by VirtPlatformTombstoneStuck alert to detect stuck tombstone deletions.
@@ -114,9 +128,28 @@ var (
 		},
 		[]string{"kind", "name", "namespace"},
 	)
+
+	// MachineConfigPoolDegraded tracks MachineConfigPools that hold back node-level assets.
+	// 1 = degraded (MachineConfig/KubeletConfig changes wait for an administrator), 0 = healthy
+	// Pools targeted by managed node-level objects are reported on every pass.
+	MachineConfigPoolDegraded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "machine_config_pool_degraded",
+			Help:      "MachineConfigPools blocking node-level changes because they are degraded (1=degraded, 0=healthy)",
+		},
+		[]string{"pool"},
+	)
 )
 
 const (
+	// UnknownManager is the manager label value when the competing field manager is not known
+	UnknownManager = "unknown"
+
+	// Pause kinds
+	PauseAutoResume = "auto-resume"
+	PausePermanent  = "permanent"
+
 	// Tombstone status values
 	TombstoneExists  = 1.0
 	TombstoneDeleted = 0.0
@@ -134,7 +167,9 @@ func init() {
 		CustomizationInfo,
 		MissingDependency,
 		ReconcileDuration,
+		ReconcilePassDuration,
 		TombstoneStatus,
+		MachineConfigPoolDegraded,
 	)
 }
 
@@ -150,11 +185,16 @@ func SetCompliance(obj *unstructured.Unstructured, status float64) {
 
 // IncThrashing increments the thrashing counter for a managed resource.
 // Called when token bucket is exhausted (anti-thrashing gate triggered).
-func IncThrashing(obj *unstructured.Unstructured) {
+// manager is the competing field manager, an empty manager is reported as "unknown".
+func IncThrashing(obj *unstructured.Unstructured, manager string) {
+	if manager == "" {
+		manager = UnknownManager
+	}
 	ThrashingTotal.WithLabelValues(
 		obj.GetKind(),
 		obj.GetName(),
 		obj.GetNamespace(),
+		manager,
 	).Inc()
 }
 
@@ -212,6 +252,11 @@ func ReconcileDurationTimer(obj *unstructured.Unstructured) *prometheus.Timer {
 	))
 }
 
+// ObserveReconcilePassDuration records the duration of a whole reconciliation pass.
+func ObserveReconcilePassDuration(duration time.Duration) {
+	ReconcilePassDuration.Observe(duration.Seconds())
+}
+
 // SetTombstoneStatus sets the tombstone deletion status for a resource.
 // status: TombstoneExists (1), TombstoneDeleted (0), TombstoneError (-1), TombstoneSkipped (-2)
 func SetTombstoneStatus(obj *unstructured.Unstructured, status float64) {
@@ -223,15 +268,34 @@ func SetTombstoneStatus(obj *unstructured.Unstructured, status float64) {
 }
 
 // SetPaused sets the paused state for a resource.
-// Called when edit war is detected (paused=true) or when annotation is removed (paused=false).
-func SetPaused(obj *unstructured.Unstructured, paused bool) {
+// pause: PauseAutoResume or PausePermanent, ignored when the resource is active.
+// The series of the previous state is replaced, so a resource has a single series.
+func SetPaused(obj *unstructured.Unstructured, paused bool, pause string) {
+	PausedResources.DeletePartialMatch(prometheus.Labels{
+		"kind":      obj.GetKind(),
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	})
+
 	value := 0.0
 	if paused {
 		value = 1.0
+	} else {
+		pause = ""
 	}
 	PausedResources.WithLabelValues(
 		obj.GetKind(),
 		obj.GetName(),
 		obj.GetNamespace(),
+		pause,
 	).Set(value)
 }
+
+// SetMachineConfigPoolDegraded marks a MachineConfigPool as degraded (1) or healthy (0).
+func SetMachineConfigPoolDegraded(pool string, degraded bool) {
+	value := 0.0
+	if degraded {
+		value = 1.0
+	}
+	MachineConfigPoolDegraded.WithLabelValues(pool).Set(value)
+}
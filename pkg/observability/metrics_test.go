@@ -71,14 +71,16 @@ func TestIncThrashing(t *testing.T) {
 	obj.SetNamespace("test-ns")
 
 	// Increment thrashing counter 3 times
-	IncThrashing(obj)
-	IncThrashing(obj)
-	IncThrashing(obj)
+	IncThrashing(obj, "argocd-controller")
+	IncThrashing(obj, "argocd-controller")
+	IncThrashing(obj, "argocd-controller")
+	IncThrashing(obj, "")
 
 	expected := `
 		# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 		# TYPE virt_platform_thrashing_total counter
-		virt_platform_thrashing_total{kind="Deployment",name="test-deploy",namespace="test-ns"} 3
+		virt_platform_thrashing_total{kind="Deployment",manager="argocd-controller",name="test-deploy",namespace="test-ns"} 3
+		virt_platform_thrashing_total{kind="Deployment",manager="unknown",name="test-deploy",namespace="test-ns"} 1
 	`
 
 	if err := testutil.CollectAndCompare(ThrashingTotal, strings.NewReader(expected)); err != nil {
@@ -95,26 +97,39 @@ func TestSetPaused(t *testing.T) {
 	obj.SetName("cluster")
 	obj.SetNamespace("openshift-kube-descheduler-operator")
 
-	// Set paused to true
-	SetPaused(obj, true)
+	// Pause with an automatic resume scheduled
+	SetPaused(obj, true, PauseAutoResume)
 
 	expected := `
 		# HELP virt_platform_paused_resources Resources currently paused due to edit war detection (1=paused, 0=active)
 		# TYPE virt_platform_paused_resources gauge
-		virt_platform_paused_resources{kind="KubeDescheduler",name="cluster",namespace="openshift-kube-descheduler-operator"} 1
+		virt_platform_paused_resources{kind="KubeDescheduler",name="cluster",namespace="openshift-kube-descheduler-operator",pause="auto-resume"} 1
 	`
 
 	if err := testutil.CollectAndCompare(PausedResources, strings.NewReader(expected)); err != nil {
 		t.Errorf("unexpected metric value when paused: %v", err)
 	}
 
+	// Escalate to a permanent pause: still 1, the pause label changes
+	SetPaused(obj, true, PausePermanent)
+
+	expected = `
+		# HELP virt_platform_paused_resources Resources currently paused due to edit war detection (1=paused, 0=active)
+		# TYPE virt_platform_paused_resources gauge
+		virt_platform_paused_resources{kind="KubeDescheduler",name="cluster",namespace="openshift-kube-descheduler-operator",pause="permanent"} 1
+	`
+
+	if err := testutil.CollectAndCompare(PausedResources, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metric value when paused permanently: %v", err)
+	}
+
 	// Set paused to false (resume)
-	SetPaused(obj, false)
+	SetPaused(obj, false, "")
 
 	expected = `
 		# HELP virt_platform_paused_resources Resources currently paused due to edit war detection (1=paused, 0=active)
 		# TYPE virt_platform_paused_resources gauge
-		virt_platform_paused_resources{kind="KubeDescheduler",name="cluster",namespace="openshift-kube-descheduler-operator"} 0
+		virt_platform_paused_resources{kind="KubeDescheduler",name="cluster",namespace="openshift-kube-descheduler-operator",pause=""} 0
 	`
 
 	if err := testutil.CollectAndCompare(PausedResources, strings.NewReader(expected)); err != nil {
@@ -262,6 +277,24 @@ func TestReconcileDurationTimer(t *testing.T) {
 	}
 }
 
+func TestObserveReconcilePassDuration(t *testing.T) {
+	before, err := collectMetrics(ReconcilePassDuration)
+	if err != nil {
+		t.Fatalf("failed to collect: %v", err)
+	}
+	count := before[0].Metric[0].Histogram.GetSampleCount()
+
+	ObserveReconcilePassDuration(3 * time.Second)
+
+	after, err := collectMetrics(ReconcilePassDuration)
+	if err != nil {
+		t.Fatalf("failed to collect: %v", err)
+	}
+	if got := after[0].Metric[0].Histogram.GetSampleCount(); got != count+1 {
+		t.Errorf("expected %d pass duration samples, got %d", count+1, got)
+	}
+}
+
 func TestMultipleResources(t *testing.T) {
 	// Reset metrics before test
 	ComplianceStatus.Reset()
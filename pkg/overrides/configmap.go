@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AnnotationOverridesConfigMap is the HCO annotation naming a ConfigMap with per-asset overrides
+	// The ConfigMap lives in the autopilot namespace. Each data key is an asset name, each value
+	// a YAML document with the overrides of that asset (see AssetOverrides).
+	AnnotationOverridesConfigMap = "platform.kubevirt.io/overrides-configmap"
+)
+
+// AssetOverrides are the overrides of one asset in the overrides ConfigMap
+// Fields are named after the annotations they stand for and accept the same values.
+//
+// Example ConfigMap entry:
+//
+//	descheduler-loadaware: |
+//	  merge-patch: '{"spec": {"deschedulingIntervalSeconds": 120}}'
+//	  ignore-fields: /spec/profileCustomizations
+type AssetOverrides struct {
+	Patch               string `json:"patch,omitempty"`
+	MergePatch          string `json:"merge-patch,omitempty"`
+	StrategicMergePatch string `json:"strategic-merge-patch,omitempty"`
+	IgnoreFields        string `json:"ignore-fields,omitempty"`
+	Mode                string `json:"mode,omitempty"`
+}
+
+// Annotations returns the overrides keyed by the annotations they stand for
+func (o AssetOverrides) Annotations() map[string]string {
+	annotations := map[string]string{
+		PatchAnnotation:               o.Patch,
+		MergePatchAnnotation:          o.MergePatch,
+		StrategicMergePatchAnnotation: o.StrategicMergePatch,
+		AnnotationIgnoreFields:        o.IgnoreFields,
+		AnnotationMode:                o.Mode,
+	}
+	for key, value := range annotations {
+		if value == "" {
+			delete(annotations, key)
+		}
+	}
+	return annotations
+}
+
+// ParseOverridesConfigMap parses the data of an overrides ConfigMap into overrides per asset name
+// Unknown fields are rejected, so that a typo doesn't silently drop an override.
+func ParseOverridesConfigMap(data map[string]string) (map[string]AssetOverrides, error) {
+	assetNames := make([]string, 0, len(data))
+	for name := range data {
+		assetNames = append(assetNames, name)
+	}
+	sort.Strings(assetNames)
+
+	result := make(map[string]AssetOverrides, len(data))
+	var errs []string
+	for _, name := range assetNames {
+		var entry AssetOverrides
+		if err := yaml.UnmarshalStrict([]byte(data[name]), &entry); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		result[name] = entry
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid overrides for %s", strings.Join(errs, "; "))
+	}
+	return result, nil
+}
+
+// MergeOverrides combines ConfigMap overrides with the annotations of an object
+// An override annotation on the object takes precedence over the ConfigMap entry of the same
+// kind: any patch annotation replaces the ConfigMap patch, whatever its format, and
+// ignore-fields and mode annotations replace their ConfigMap counterparts.
+func MergeOverrides(configured AssetOverrides, annotations map[string]string) map[string]string {
+	merged := make(map[string]string, len(annotations)+5)
+	for key, value := range annotations {
+		merged[key] = value
+	}
+
+	hasPatch := false
+	for _, annotation := range PatchAnnotations {
+		if _, exists := annotations[annotation]; exists {
+			hasPatch = true
+		}
+	}
+
+	for key, value := range configured.Annotations() {
+		if _, exists := annotations[key]; exists {
+			continue
+		}
+		if hasPatch && PatchFormat(key) != "" {
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOverridesConfigMap(t *testing.T) {
+	t.Run("valid entries", func(t *testing.T) {
+		got, err := ParseOverridesConfigMap(map[string]string{
+			"swap-enable": "mode: unmanaged\n",
+			"descheduler-loadaware": `merge-patch: '{"spec": {"deschedulingIntervalSeconds": 120}}'
+ignore-fields: /spec/profileCustomizations
+`,
+		})
+		if err != nil {
+			t.Fatalf("ParseOverridesConfigMap() error = %v", err)
+		}
+
+		want := map[string]AssetOverrides{
+			"swap-enable": {Mode: "unmanaged"},
+			"descheduler-loadaware": {
+				MergePatch:   `{"spec": {"deschedulingIntervalSeconds": 120}}`,
+				IgnoreFields: "/spec/profileCustomizations",
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseOverridesConfigMap() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		_, err := ParseOverridesConfigMap(map[string]string{
+			"swap-enable":     "mode: unmanaged\n",
+			"pci-passthrough": "ignore-field: /spec\n",
+		})
+		if err == nil || !strings.Contains(err.Error(), "pci-passthrough") {
+			t.Errorf("ParseOverridesConfigMap() error = %v, want error naming pci-passthrough", err)
+		}
+	})
+}
+
+func TestMergeOverrides(t *testing.T) {
+	configured := AssetOverrides{
+		MergePatch:   `{"spec": {"a": 1}}`,
+		IgnoreFields: "/spec/b",
+		Mode:         "unmanaged",
+	}
+
+	t.Run("ConfigMap entries fill in missing annotations", func(t *testing.T) {
+		got := MergeOverrides(configured, map[string]string{"other": "value"})
+		want := map[string]string{
+			"other":                "value",
+			MergePatchAnnotation:   `{"spec": {"a": 1}}`,
+			AnnotationIgnoreFields: "/spec/b",
+			AnnotationMode:         "unmanaged",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("MergeOverrides() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("annotations take precedence", func(t *testing.T) {
+		got := MergeOverrides(configured, map[string]string{
+			PatchAnnotation:        `[{"op": "add", "path": "/spec/c", "value": 1}]`,
+			AnnotationIgnoreFields: "/spec/d",
+		})
+		want := map[string]string{
+			PatchAnnotation:        `[{"op": "add", "path": "/spec/c", "value": 1}]`,
+			AnnotationIgnoreFields: "/spec/d",
+			AnnotationMode:         "unmanaged",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("MergeOverrides() = %v, want %v", got, want)
+		}
+	})
+}
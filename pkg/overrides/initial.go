@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AnnotationInitialOverrides is the HCO annotation with overrides for objects that don't exist yet
+	// The value is a YAML map keyed by <kind>/<namespace>/<name>, or <kind>/<name> for cluster-scoped
+	// objects, with the same fields as an overrides ConfigMap entry (see AssetOverrides).
+	// The overrides are written as annotations on the object when it is created, so its first
+	// write is already customized; afterwards the annotations on the object apply.
+	AnnotationInitialOverrides = "platform.kubevirt.io/initial-overrides"
+)
+
+// InitialOverridesKey returns the key of an object in the initial-overrides annotation
+func InitialOverridesKey(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// ParseInitialOverrides parses the initial-overrides annotation into overrides per object key
+// Entries with unknown fields or malformed keys are left out and reported in the error, so that
+// a typo doesn't silently drop an override, while the valid entries are still returned.
+func ParseInitialOverrides(annotation string) (map[string]AssetOverrides, error) {
+	if strings.TrimSpace(annotation) == "" {
+		return nil, nil
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(annotation), &raw); err != nil {
+		return nil, fmt.Errorf("invalid initial overrides: %w", err)
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make(map[string]AssetOverrides, len(raw))
+	var errs []error
+	for _, key := range keys {
+		parts := strings.Split(key, "/")
+		if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+			errs = append(errs, fmt.Errorf("invalid initial overrides key %q: expected <kind>/<namespace>/<name> or <kind>/<name>", key))
+			continue
+		}
+
+		entry, err := parseInitialOverridesEntry(raw[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid initial overrides for %s: %w", key, err))
+			continue
+		}
+		entries[key] = entry
+	}
+	return entries, utilerrors.NewAggregate(errs)
+}
+
+// parseInitialOverridesEntry decodes a single entry of the annotation, rejecting unknown fields
+func parseInitialOverridesEntry(value interface{}) (AssetOverrides, error) {
+	var entry AssetOverrides
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return entry, err
+	}
+	err = yaml.UnmarshalStrict(data, &entry)
+	return entry, err
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInitialOverridesKey(t *testing.T) {
+	if got := InitialOverridesKey("MachineConfig", "", "90-worker-swap-online"); got != "MachineConfig/90-worker-swap-online" {
+		t.Errorf("cluster-scoped key = %s", got)
+	}
+	if got := InitialOverridesKey("PrometheusRule", "openshift-cnv", "virt-platform-autopilot-alerts"); got != "PrometheusRule/openshift-cnv/virt-platform-autopilot-alerts" {
+		t.Errorf("namespaced key = %s", got)
+	}
+}
+
+func TestParseInitialOverrides(t *testing.T) {
+	got, err := ParseInitialOverrides(`
+MachineConfig/90-worker-swap-online:
+  merge-patch: '{"spec": {"kernelArguments": ["iommu=pt"]}}'
+PrometheusRule/openshift-cnv/virt-platform-autopilot-alerts:
+  mode: unmanaged
+`)
+	if err != nil {
+		t.Fatalf("ParseInitialOverrides() error = %v", err)
+	}
+	want := map[string]AssetOverrides{
+		"MachineConfig/90-worker-swap-online":                         {MergePatch: `{"spec": {"kernelArguments": ["iommu=pt"]}}`},
+		"PrometheusRule/openshift-cnv/virt-platform-autopilot-alerts": {Mode: "unmanaged"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseInitialOverrides() = %+v, want %+v", got, want)
+	}
+
+	if got, err := ParseInitialOverrides(""); got != nil || err != nil {
+		t.Errorf("ParseInitialOverrides(\"\") = %v, %v, want nothing", got, err)
+	}
+
+	if _, err := ParseInitialOverrides("- MachineConfig/90-worker-swap-online\n"); err == nil {
+		t.Error("ParseInitialOverrides() of a YAML list should fail")
+	}
+
+	// A malformed entry is reported and skipped, the valid entries next to it are kept
+	valid := "PrometheusRule/openshift-cnv/virt-platform-autopilot-alerts:\n  mode: unmanaged\n"
+	invalid := map[string]string{
+		"unknown field":  "MachineConfig/90-worker-swap-online:\n  merge-pach: '{}'\n",
+		"kind only":      "MachineConfig:\n  mode: unmanaged\n",
+		"empty name":     "MachineConfig/:\n  mode: unmanaged\n",
+		"too many parts": "PrometheusRule/a/b/c:\n  mode: unmanaged\n",
+	}
+	for name, annotation := range invalid {
+		got, err := ParseInitialOverrides(valid + annotation)
+		if err == nil {
+			t.Errorf("%s: ParseInitialOverrides() should report the malformed entry", name)
+		}
+		want := map[string]AssetOverrides{"PrometheusRule/openshift-cnv/virt-platform-autopilot-alerts": {Mode: "unmanaged"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ParseInitialOverrides() = %+v, want %+v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+const (
+	// MergePatchAnnotation is the annotation key for RFC 7386 JSON Merge Patch
+	MergePatchAnnotation = "platform.kubevirt.io/merge-patch"
+
+	// StrategicMergePatchAnnotation is the annotation key for strategic merge patch
+	// List merge keys come from the vendored CRD schemas (x-kubernetes-list-map-keys)
+	StrategicMergePatchAnnotation = "platform.kubevirt.io/strategic-merge-patch"
+)
+
+// PatchAnnotations are the override annotations, one per patch format
+// At most one of them may be set on an object.
+var PatchAnnotations = []string{PatchAnnotation, MergePatchAnnotation, StrategicMergePatchAnnotation}
+
+// patchFormats names the patch format of each patch annotation, for messages
+var patchFormats = map[string]string{
+	PatchAnnotation:               "JSON patch",
+	MergePatchAnnotation:          "JSON merge patch",
+	StrategicMergePatchAnnotation: "strategic merge patch",
+}
+
+// PatchFormat returns the name of the patch format of a patch annotation
+func PatchFormat(annotation string) string {
+	return patchFormats[annotation]
+}
+
+// opaqueListMergeKeys are merge keys of lists in fields the CRD schemas leave opaque
+// MachineConfig embeds an Ignition config (x-kubernetes-preserve-unknown-fields),
+// whose lists are keyed by path or name.
+var opaqueListMergeKeys = map[string]map[string]string{
+	"MachineConfig": {
+		"spec.config.passwd.users":          "name",
+		"spec.config.storage.directories":   "path",
+		"spec.config.storage.files":         "path",
+		"spec.config.storage.links":         "path",
+		"spec.config.systemd.units":         "name",
+		"spec.config.systemd.units.dropins": "name",
+	},
+}
+
+// ApplyMergePatch applies a RFC 7386 JSON Merge Patch from the object's annotation
+// The patch is applied in-memory to the provided object
+// Returns true if a patch was applied, false if no merge-patch annotation exists
+func ApplyMergePatch(obj *unstructured.Unstructured) (bool, error) {
+	if obj == nil {
+		return false, fmt.Errorf("object is nil")
+	}
+
+	patchStr := obj.GetAnnotations()[MergePatchAnnotation]
+	if patchStr == "" {
+		return false, nil
+	}
+
+	if err := ValidateMergePatch(patchStr); err != nil {
+		return false, fmt.Errorf("invalid JSON Merge Patch in annotation: %w", err)
+	}
+
+	originalJSON, err := json.Marshal(obj.Object)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal object to JSON: %w", err)
+	}
+
+	patchedJSON, err := jsonpatch.MergePatch(originalJSON, []byte(patchStr))
+	if err != nil {
+		return false, fmt.Errorf("failed to apply JSON Merge Patch: %w", err)
+	}
+
+	var patchedObj map[string]interface{}
+	if err := json.Unmarshal(patchedJSON, &patchedObj); err != nil {
+		return false, fmt.Errorf("failed to unmarshal patched JSON: %w", err)
+	}
+
+	obj.Object = patchedObj
+
+	return true, nil
+}
+
+// ValidateMergePatch validates that a JSON Merge Patch string is a JSON object
+// RFC 7386 allows any JSON value, but anything else would replace the whole object.
+func ValidateMergePatch(patchStr string) error {
+	if patchStr == "" {
+		return nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return fmt.Errorf("merge patch must be a JSON object: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyStrategicMergePatch applies a strategic merge patch from the object's annotation
+// Lists are merged by the merge keys found in schema, other lists are replaced as in a
+// JSON Merge Patch. The object is left unchanged if the patch can't be applied.
+// Returns true if a patch was applied, false if no strategic-merge-patch annotation exists
+func ApplyStrategicMergePatch(obj *unstructured.Unstructured, schema strategicpatch.LookupPatchMeta) (bool, error) {
+	if obj == nil {
+		return false, fmt.Errorf("object is nil")
+	}
+
+	patchStr := obj.GetAnnotations()[StrategicMergePatchAnnotation]
+	if patchStr == "" {
+		return false, nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return false, fmt.Errorf("invalid strategic merge patch in annotation: %w", err)
+	}
+
+	// The merge works in place, patch a copy so a failure leaves the object intact
+	patchedObj, err := strategicpatch.StrategicMergeMapPatchUsingLookupPatchMeta(obj.DeepCopy().Object, patch, schema)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply strategic merge patch: %w", err)
+	}
+
+	obj.Object = patchedObj
+
+	return true, nil
+}
+
+// ValidateStrategicMergePatch validates that a strategic merge patch string is a JSON object
+func ValidateStrategicMergePatch(patchStr string) error {
+	if patchStr == "" {
+		return nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return fmt.Errorf("strategic merge patch must be a JSON object: %w", err)
+	}
+
+	return nil
+}
+
+// schemaPatchMeta looks up strategic merge metadata in a CRD OpenAPI schema
+// Lists with x-kubernetes-list-type "map" and a single map key are merged by that key,
+// "set" lists of scalars are merged as sets. All other lists, and lists in fields the
+// schema doesn't describe, are replaced.
+type schemaPatchMeta struct {
+	kind   string
+	path   string // Dotted field path from the object root, without list indices
+	schema *apiextensionsv1.JSONSchemaProps
+}
+
+var _ strategicpatch.LookupPatchMeta = schemaPatchMeta{}
+
+// NewSchemaPatchMeta returns strategic merge metadata for a kind described by a CRD schema
+// A nil schema replaces every list, except known lists in opaque fields.
+func NewSchemaPatchMeta(kind string, schema *apiextensionsv1.JSONSchemaProps) strategicpatch.LookupPatchMeta {
+	return schemaPatchMeta{kind: kind, schema: schema}
+}
+
+// LookupPatchMetadataForStruct returns the metadata of a nested object field
+func (s schemaPatchMeta) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return s.child(key), strategicpatch.PatchMeta{}, nil
+}
+
+// LookupPatchMetadataForSlice returns the metadata of the items of a list field
+func (s schemaPatchMeta) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	field := s.child(key)
+	items := schemaPatchMeta{kind: s.kind, path: field.path}
+	if field.schema != nil && field.schema.Items != nil {
+		items.schema = field.schema.Items.Schema
+	}
+
+	meta := strategicpatch.PatchMeta{}
+	switch {
+	case field.schema != nil && field.schema.XListType != nil && *field.schema.XListType == "map":
+		if len(field.schema.XListMapKeys) == 1 {
+			meta.SetPatchStrategies([]string{"merge"})
+			meta.SetPatchMergeKey(field.schema.XListMapKeys[0])
+		}
+	case field.schema != nil && field.schema.XListType != nil && *field.schema.XListType == "set":
+		if items.schema != nil && items.schema.Type != "object" && items.schema.Type != "array" {
+			meta.SetPatchStrategies([]string{"merge"})
+		}
+	case opaqueListMergeKeys[s.kind][field.path] != "":
+		meta.SetPatchStrategies([]string{"merge"})
+		meta.SetPatchMergeKey(opaqueListMergeKeys[s.kind][field.path])
+	}
+
+	return items, meta, nil
+}
+
+// Name returns the kind the schema belongs to
+func (s schemaPatchMeta) Name() string {
+	return s.kind
+}
+
+// child returns the metadata of a field, with a nil schema if the field is not described
+func (s schemaPatchMeta) child(key string) schemaPatchMeta {
+	child := schemaPatchMeta{kind: s.kind, path: key}
+	if s.path != "" {
+		child.path = s.path + "." + key
+	}
+	if s.schema == nil {
+		return child
+	}
+
+	if prop, ok := s.schema.Properties[key]; ok {
+		child.schema = &prop
+	} else if s.schema.AdditionalProperties != nil {
+		child.schema = s.schema.AdditionalProperties.Schema
+	}
+	return child
+}
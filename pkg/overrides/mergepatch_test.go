@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPatchedObject(kind, annotation, patch string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.io/v1",
+		"kind":       kind,
+		"spec":       spec,
+	}}
+	obj.SetName("test")
+	if annotation != "" {
+		obj.SetAnnotations(map[string]string{annotation: patch})
+	}
+	return obj
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	spec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"mode":     "Predictive",
+			"interval": int64(60),
+			"profiles": []interface{}{"LongLifecycle", "EvictPodsWithPVC"},
+		}
+	}
+
+	t.Run("no annotation", func(t *testing.T) {
+		obj := newPatchedObject("KubeDescheduler", "", "", spec())
+		if applied, err := ApplyMergePatch(obj); applied || err != nil {
+			t.Errorf("ApplyMergePatch() = %v, %v, want nothing applied", applied, err)
+		}
+	})
+
+	t.Run("merge, delete and replace list", func(t *testing.T) {
+		obj := newPatchedObject("KubeDescheduler", MergePatchAnnotation,
+			`{"spec": {"mode": "Automatic", "interval": null, "profiles": ["DevKubeVirtRelieveAndMigrate"]}}`, spec())
+		applied, err := ApplyMergePatch(obj)
+		if !applied || err != nil {
+			t.Fatalf("ApplyMergePatch() = %v, %v", applied, err)
+		}
+
+		want := map[string]interface{}{
+			"mode":     "Automatic",
+			"profiles": []interface{}{"DevKubeVirtRelieveAndMigrate"},
+		}
+		if got := obj.Object["spec"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("spec = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		obj := newPatchedObject("KubeDescheduler", MergePatchAnnotation, `"replace everything"`, spec())
+		if _, err := ApplyMergePatch(obj); err == nil {
+			t.Error("ApplyMergePatch() should reject a patch that is not an object")
+		}
+	})
+}
+
+func TestApplyStrategicMergePatch(t *testing.T) {
+	mapList := "map"
+	setList := "set"
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"groups": {
+						Type:         "array",
+						XListType:    &mapList,
+						XListMapKeys: []string{"name"},
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"name":     {Type: "string"},
+								"interval": {Type: "string"},
+								"rules":    {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}}},
+							},
+						}},
+					},
+					"tags": {
+						Type:      "array",
+						XListType: &setList,
+						Items:     &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}},
+					},
+				},
+			},
+		},
+	}
+	spec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{"name": "kubevirt.rules", "interval": "30s", "rules": []interface{}{map[string]interface{}{"alert": "A"}}},
+				map[string]interface{}{"name": "node.rules", "interval": "1m"},
+			},
+			"tags": []interface{}{"virt"},
+		}
+	}
+
+	t.Run("merges lists by their map key", func(t *testing.T) {
+		// The patch doesn't depend on the position of node.rules in the list
+		obj := newPatchedObject("PrometheusRule", StrategicMergePatchAnnotation,
+			`{"spec": {"groups": [{"name": "node.rules", "interval": "5m"}], "tags": ["platform"]}}`, spec())
+		applied, err := ApplyStrategicMergePatch(obj, NewSchemaPatchMeta("PrometheusRule", schema))
+		if !applied || err != nil {
+			t.Fatalf("ApplyStrategicMergePatch() = %v, %v", applied, err)
+		}
+
+		groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 groups, got %v", groups)
+		}
+		first := groups[0].(map[string]interface{})
+		second := groups[1].(map[string]interface{})
+		if first["interval"] != "30s" || len(first["rules"].([]interface{})) != 1 {
+			t.Errorf("kubevirt.rules should be untouched, got %v", first)
+		}
+		if second["interval"] != "5m" {
+			t.Errorf("node.rules interval = %v, want 5m", second["interval"])
+		}
+
+		tags, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "tags")
+		sort.Strings(tags)
+		if !reflect.DeepEqual(tags, []string{"platform", "virt"}) {
+			t.Errorf("tags = %v, want both tags", tags)
+		}
+	})
+
+	t.Run("delete directive", func(t *testing.T) {
+		obj := newPatchedObject("PrometheusRule", StrategicMergePatchAnnotation,
+			`{"spec": {"groups": [{"name": "kubevirt.rules", "$patch": "delete"}]}}`, spec())
+		if _, err := ApplyStrategicMergePatch(obj, NewSchemaPatchMeta("PrometheusRule", schema)); err != nil {
+			t.Fatalf("ApplyStrategicMergePatch() error = %v", err)
+		}
+		groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+		if len(groups) != 1 || groups[0].(map[string]interface{})["name"] != "node.rules" {
+			t.Errorf("groups = %v, want only node.rules", groups)
+		}
+	})
+
+	t.Run("failure leaves the object intact", func(t *testing.T) {
+		// A list of objects without a merge key can't be merged
+		obj := newPatchedObject("PrometheusRule", StrategicMergePatchAnnotation,
+			`{"spec": {"groups": [{"interval": "5m"}]}}`, spec())
+		before := obj.DeepCopy()
+		if _, err := ApplyStrategicMergePatch(obj, NewSchemaPatchMeta("PrometheusRule", schema)); err == nil {
+			t.Fatal("ApplyStrategicMergePatch() should fail for a list item without merge key")
+		}
+		if !reflect.DeepEqual(obj.Object, before.Object) {
+			t.Error("object should not be modified by a failed patch")
+		}
+	})
+}
+
+func TestStrategicMergePatchOpaqueFields(t *testing.T) {
+	// Ignition configs are opaque in the MachineConfig CRD, units are merged by name anyway
+	spec := map[string]interface{}{
+		"config": map[string]interface{}{
+			"systemd": map[string]interface{}{
+				"units": []interface{}{
+					map[string]interface{}{"name": "a.service", "enabled": true},
+					map[string]interface{}{"name": "b.service", "contents": "[Unit]"},
+				},
+			},
+		},
+		"kernelArguments": []interface{}{"intel_iommu=on"},
+	}
+	obj := newPatchedObject("MachineConfig", StrategicMergePatchAnnotation,
+		`{"spec": {"config": {"systemd": {"units": [{"name": "b.service", "contents": "[Unit]\nAfter=network.target"}]}}, "kernelArguments": ["iommu=pt"]}}`, spec)
+
+	if _, err := ApplyStrategicMergePatch(obj, NewSchemaPatchMeta("MachineConfig", nil)); err != nil {
+		t.Fatalf("ApplyStrategicMergePatch() error = %v", err)
+	}
+
+	units, _, _ := unstructured.NestedSlice(obj.Object, "spec", "config", "systemd", "units")
+	if len(units) != 2 || units[1].(map[string]interface{})["contents"] != "[Unit]\nAfter=network.target" {
+		t.Errorf("units = %v, want b.service patched in place", units)
+	}
+
+	// Lists without known merge key are replaced
+	args, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "kernelArguments")
+	if !reflect.DeepEqual(args, []string{"iommu=pt"}) {
+		t.Errorf("kernelArguments = %v, want replaced list", args)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// PatchPolicyConfigMapName is the ConfigMap in PatchPolicyNamespace holding the patch policy
+	// Its name is fixed, so that whoever may annotate the HCO can't pick another policy.
+	PatchPolicyConfigMapName = "virt-platform-autopilot-patch-policy"
+
+	// PatchPolicyNamespace is the namespace of the patch policy ConfigMap
+	// It is kept apart from the autopilot namespace, where the overrides ConfigMap is written:
+	// RBAC can't restrict creating ConfigMaps by name, so anyone able to write the overrides
+	// could also create a policy there. Only cluster admins should be able to write this namespace.
+	PatchPolicyNamespace = "virt-platform-autopilot-policy"
+
+	// wildcardToken matches any single reference token of a JSON pointer in a policy path
+	wildcardToken = "*"
+)
+
+// PatchPolicy lists the JSON pointer paths patches may write to, per sensitive kind
+// Patches on sensitive kinds are blocked, unless every path they write is at or below an
+// allowed path. A "*" token in an allowed path matches any single token (e.g. a list index).
+//
+// Example ConfigMap data:
+//
+//	KubeletConfig: /spec/kubeletConfig/maxPods
+//	MachineConfig: |
+//	  /spec/kernelArguments
+//	  /spec/config/systemd/units/*/contents
+type PatchPolicy struct {
+	allowedPaths map[string][]string
+}
+
+// ParsePatchPolicy parses the data of the patch policy ConfigMap
+// Keys must be sensitive kinds, values are JSON pointers separated by newlines or commas.
+func ParsePatchPolicy(data map[string]string) (*PatchPolicy, error) {
+	policy := &PatchPolicy{allowedPaths: make(map[string][]string, len(data))}
+
+	kinds := make([]string, 0, len(data))
+	for kind := range data {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		if !sensitiveKinds[kind] {
+			return nil, fmt.Errorf("%s is not a sensitive resource kind, its patches need no policy", kind)
+		}
+		pointers := strings.ReplaceAll(data[kind], "\n", ",")
+		if err := ValidatePointers(pointers); err != nil {
+			return nil, fmt.Errorf("invalid paths for %s: %w", kind, err)
+		}
+		policy.allowedPaths[kind] = parsePointers(pointers)
+	}
+
+	return policy, nil
+}
+
+// AllowedPaths returns the paths patches may write to on a sensitive kind
+// A nil policy allows none.
+func (p *PatchPolicy) AllowedPaths(kind string) []string {
+	if p == nil {
+		return nil
+	}
+	return p.allowedPaths[kind]
+}
+
+// pathAllowed reports whether path is at or below one of the allowed paths
+func pathAllowed(path string, allowed []string) bool {
+	tokens := strings.Split(path, "/")
+	for _, allowedPath := range allowed {
+		allowedTokens := strings.Split(allowedPath, "/")
+		if len(allowedTokens) > len(tokens) {
+			continue
+		}
+		matches := true
+		for i, token := range allowedTokens {
+			if token != wildcardToken && token != tokens[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// patchedPaths returns the JSON pointers of the fields a patch annotation writes to
+// The whole object is written to by a patch at its root, reported as "".
+func patchedPaths(annotation, patchStr string) ([]string, error) {
+	switch annotation {
+	case PatchAnnotation:
+		var operations []struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+			From string `json:"from"`
+		}
+		if err := json.Unmarshal([]byte(patchStr), &operations); err != nil {
+			return nil, fmt.Errorf("invalid JSON Patch: %w", err)
+		}
+		var paths []string
+		for _, operation := range operations {
+			switch operation.Op {
+			case "test":
+				// Only reads
+			case "move":
+				paths = append(paths, operation.From, operation.Path)
+			default:
+				paths = append(paths, operation.Path)
+			}
+		}
+		return paths, nil
+
+	case MergePatchAnnotation, StrategicMergePatchAnnotation:
+		var patch map[string]interface{}
+		if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+			return nil, fmt.Errorf("%s must be a JSON object: %w", PatchFormat(annotation), err)
+		}
+		var paths []string
+		collectMergePaths("", patch, annotation == StrategicMergePatchAnnotation, &paths)
+		sort.Strings(paths)
+		return paths, nil
+	}
+
+	return nil, fmt.Errorf("unknown patch annotation %s", annotation)
+}
+
+// collectMergePaths adds the leaf paths of a merge patch object below path
+// Nested objects are merged field by field; any other value, lists included, replaces or
+// deletes the field. Strategic merge directives ($patch, $retainKeys, ...) write to the object
+// they are in, $setElementOrder/<field> and $deleteFromPrimitiveList/<field> to that field.
+func collectMergePaths(path string, patch map[string]interface{}, strategic bool, paths *[]string) {
+	for key, value := range patch {
+		if strategic && strings.HasPrefix(key, "$") {
+			if _, field, found := strings.Cut(key, "/"); found {
+				*paths = append(*paths, path+"/"+escapePointerToken(field))
+			} else {
+				*paths = append(*paths, path)
+			}
+			continue
+		}
+
+		fieldPath := path + "/" + escapePointerToken(key)
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			collectMergePaths(fieldPath, nested, strategic, paths)
+			continue
+		}
+		*paths = append(*paths, fieldPath)
+	}
+}
+
+// escapePointerToken escapes a field name as a JSON pointer reference token (RFC 6901)
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePatchPolicy(t *testing.T) {
+	policy, err := ParsePatchPolicy(map[string]string{
+		"KubeletConfig": "/spec/kubeletConfig/maxPods",
+		"MachineConfig": "/spec/kernelArguments\n/spec/config/systemd/units/*/contents\n",
+	})
+	if err != nil {
+		t.Fatalf("ParsePatchPolicy() error = %v", err)
+	}
+	want := []string{"/spec/kernelArguments", "/spec/config/systemd/units/*/contents"}
+	if got := policy.AllowedPaths("MachineConfig"); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllowedPaths(MachineConfig) = %v, want %v", got, want)
+	}
+	if got := policy.AllowedPaths("ClusterRole"); got != nil {
+		t.Errorf("AllowedPaths(ClusterRole) = %v, want none", got)
+	}
+
+	var nilPolicy *PatchPolicy
+	if got := nilPolicy.AllowedPaths("MachineConfig"); got != nil {
+		t.Errorf("nil policy AllowedPaths() = %v, want none", got)
+	}
+
+	if _, err := ParsePatchPolicy(map[string]string{"Deployment": "/spec/replicas"}); err == nil {
+		t.Error("ParsePatchPolicy() should reject kinds that are not sensitive")
+	}
+	if _, err := ParsePatchPolicy(map[string]string{"MachineConfig": "spec/kernelArguments"}); err == nil {
+		t.Error("ParsePatchPolicy() should reject invalid JSON pointers")
+	}
+}
+
+func TestValidatePatchSecurityWithPolicy(t *testing.T) {
+	policy, err := ParsePatchPolicy(map[string]string{
+		"KubeletConfig": "/spec/kubeletConfig/maxPods",
+		"MachineConfig": "/spec/kernelArguments, /spec/config/systemd/units/*/contents",
+	})
+	if err != nil {
+		t.Fatalf("ParsePatchPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		kind       string
+		annotation string
+		patch      string
+		errMsg     string // empty if the patch is allowed
+	}{
+		{
+			name:       "JSON patch on allowed path",
+			kind:       "KubeletConfig",
+			annotation: PatchAnnotation,
+			patch:      `[{"op": "replace", "path": "/spec/kubeletConfig/maxPods", "value": 500}]`,
+		},
+		{
+			name:       "JSON patch below allowed path with wildcard",
+			kind:       "MachineConfig",
+			annotation: PatchAnnotation,
+			patch:      `[{"op": "add", "path": "/spec/kernelArguments/-", "value": "iommu=pt"}, {"op": "replace", "path": "/spec/config/systemd/units/0/contents", "value": "[Unit]"}]`,
+		},
+		{
+			name:       "JSON patch on other path",
+			kind:       "KubeletConfig",
+			annotation: PatchAnnotation,
+			patch:      `[{"op": "replace", "path": "/spec/kubeletConfig/maxPods", "value": 500}, {"op": "remove", "path": "/spec/machineConfigPoolSelector"}]`,
+			errMsg:     `JSON patch path "/spec/machineConfigPoolSelector" is not allowed on sensitive resource kind KubeletConfig`,
+		},
+		{
+			name:       "JSON patch moving from other path",
+			kind:       "MachineConfig",
+			annotation: PatchAnnotation,
+			patch:      `[{"op": "move", "from": "/spec/config", "path": "/spec/kernelArguments"}]`,
+			errMsg:     `path "/spec/config"`,
+		},
+		{
+			name:       "merge patch on allowed path",
+			kind:       "MachineConfig",
+			annotation: MergePatchAnnotation,
+			patch:      `{"spec": {"kernelArguments": ["iommu=pt"]}}`,
+		},
+		{
+			name:       "merge patch on other path",
+			kind:       "MachineConfig",
+			annotation: MergePatchAnnotation,
+			patch:      `{"spec": {"kernelArguments": ["iommu=pt"]}, "metadata": {"labels": {"machineconfiguration.openshift.io/role": "master"}}}`,
+			errMsg:     `JSON merge patch path "/metadata/labels/machineconfiguration.openshift.io~1role" is not allowed`,
+		},
+		{
+			name:       "strategic merge patch directive on other path",
+			kind:       "MachineConfig",
+			annotation: StrategicMergePatchAnnotation,
+			patch:      `{"spec": {"$patch": "replace", "kernelArguments": ["iommu=pt"]}}`,
+			errMsg:     `strategic merge patch path "/spec" is not allowed`,
+		},
+		{
+			name:       "kind without policy",
+			kind:       "ClusterRole",
+			annotation: PatchAnnotation,
+			patch:      `[{"op": "add", "path": "/rules/-", "value": {}}]`,
+			errMsg:     "JSON patches are not allowed on sensitive resource kind: ClusterRole",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := newPatchedObject(tt.kind, tt.annotation, tt.patch, map[string]interface{}{})
+			err := ValidatePatchSecurity(obj, policy)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidatePatchSecurity() error = %v, want allowed", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidatePatchSecurity() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
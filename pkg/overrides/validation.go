@@ -18,6 +18,9 @@ package overrides
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -32,11 +35,27 @@ const (
 	// AnnotationReconcilePaused is set when an edit war is detected
 	// The operator will skip reconciliation while this annotation is present
 	AnnotationReconcilePaused = "platform.kubevirt.io/reconcile-paused"
+
+	// AnnotationReconcilePausedBy is set alongside AnnotationReconcilePaused
+	// It names the competing field managers and the fields they changed (informational only)
+	AnnotationReconcilePausedBy = "platform.kubevirt.io/reconcile-paused-by"
+
+	// AnnotationReconcilePausedAt records when the latest pause started (RFC 3339)
+	// Kept after an automatic resume, so repeated episodes can be counted
+	AnnotationReconcilePausedAt = "platform.kubevirt.io/reconcile-paused-at"
+
+	// AnnotationReconcilePauseEpisode counts the consecutive pause episodes of a resource
+	AnnotationReconcilePauseEpisode = "platform.kubevirt.io/reconcile-pause-episode"
+
+	// AnnotationReconcileResumeAt is when a paused resource is resumed automatically (RFC 3339)
+	// A paused resource without it stays paused until the pause annotation is removed
+	AnnotationReconcileResumeAt = "platform.kubevirt.io/reconcile-resume-at"
 )
 
 var (
-	// sensitiveKinds defines resource kinds where JSON patches are blocked for security
-	// These resources have elevated privileges or control cluster security
+	// sensitiveKinds defines resource kinds where patches (of any format) are blocked for security
+	// These resources have elevated privileges or control cluster security. The patch policy
+	// may allow patches on some of their paths.
 	sensitiveKinds = map[string]bool{
 		// Machine configuration - controls node-level config
 		"MachineConfig": true,
@@ -60,19 +79,38 @@ var (
 	}
 )
 
-// ValidatePatchSecurity validates that a JSON patch is safe to apply
-// Blocks patches on sensitive resource kinds to prevent privilege escalation
-func ValidatePatchSecurity(obj *unstructured.Unstructured) error {
+// ValidatePatchSecurity validates that a patch (of any format) is safe to apply
+// Blocks patches on sensitive resource kinds to prevent privilege escalation, except for
+// patches that only write to paths the policy allows for the kind. A nil policy allows none.
+func ValidatePatchSecurity(obj *unstructured.Unstructured, policy *PatchPolicy) error {
 	if obj == nil {
 		return fmt.Errorf("object is nil")
 	}
 
 	kind := obj.GetKind()
-	if sensitiveKinds[kind] {
-		annotations := obj.GetAnnotations()
-		if annotations != nil {
-			if _, hasPatch := annotations[PatchAnnotation]; hasPatch {
-				return fmt.Errorf("JSON patches are not allowed on sensitive resource kind: %s", kind)
+	if !sensitiveKinds[kind] {
+		return nil
+	}
+
+	annotations := obj.GetAnnotations()
+	allowed := policy.AllowedPaths(kind)
+	for _, annotation := range PatchAnnotations {
+		patchStr, hasPatch := annotations[annotation]
+		if !hasPatch {
+			continue
+		}
+		if len(allowed) == 0 {
+			return fmt.Errorf("%ses are not allowed on sensitive resource kind: %s", PatchFormat(annotation), kind)
+		}
+
+		paths, err := patchedPaths(annotation, patchStr)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if !pathAllowed(path, allowed) {
+				return fmt.Errorf("%s path %q is not allowed on sensitive resource kind %s (allowed: %s)",
+					PatchFormat(annotation), path, kind, strings.Join(allowed, ", "))
 			}
 		}
 	}
@@ -111,8 +149,42 @@ func IsPaused(obj *unstructured.Unstructured) bool {
 	return exists && val == "true"
 }
 
+// GetResumeAt returns when a paused resource is resumed automatically
+// Returns false if no auto-resume is scheduled (permanent pause) or the annotation is malformed
+func GetResumeAt(obj *unstructured.Unstructured) (time.Time, bool) {
+	if obj == nil {
+		return time.Time{}, false
+	}
+
+	resumeAt, err := time.Parse(time.RFC3339, obj.GetAnnotations()[AnnotationReconcileResumeAt])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return resumeAt, true
+}
+
+// GetPauseEpisode returns the latest pause episode of a resource and when it started
+// Returns 0 and a zero time if the resource was never paused
+func GetPauseEpisode(obj *unstructured.Unstructured) (int, time.Time) {
+	if obj == nil {
+		return 0, time.Time{}
+	}
+
+	annotations := obj.GetAnnotations()
+	episode, err := strconv.Atoi(annotations[AnnotationReconcilePauseEpisode])
+	if err != nil || episode < 1 {
+		return 0, time.Time{}
+	}
+	pausedAt, err := time.Parse(time.RFC3339, annotations[AnnotationReconcilePausedAt])
+	if err != nil {
+		return 0, time.Time{}
+	}
+	return episode, pausedAt
+}
+
 // ValidateAnnotations validates all override annotations on an object
-func ValidateAnnotations(obj *unstructured.Unstructured) error {
+// Patches on sensitive kinds are checked against policy (see ValidatePatchSecurity).
+func ValidateAnnotations(obj *unstructured.Unstructured, policy *PatchPolicy) error {
 	if obj == nil {
 		return fmt.Errorf("object is nil")
 	}
@@ -122,14 +194,35 @@ func ValidateAnnotations(obj *unstructured.Unstructured) error {
 		return nil
 	}
 
-	// Validate patch annotation
+	// Validate patch annotations, only one patch format may be used at a time
+	var patchAnnotations []string
+	for _, annotation := range PatchAnnotations {
+		if _, exists := annotations[annotation]; exists {
+			patchAnnotations = append(patchAnnotations, annotation)
+		}
+	}
+	if len(patchAnnotations) > 1 {
+		return fmt.Errorf("only one patch annotation may be set, found %s", strings.Join(patchAnnotations, ", "))
+	}
 	if patchStr, exists := annotations[PatchAnnotation]; exists {
 		if err := ValidateJSONPatch(patchStr); err != nil {
 			return fmt.Errorf("invalid patch annotation: %w", err)
 		}
+	}
+	if patchStr, exists := annotations[MergePatchAnnotation]; exists {
+		if err := ValidateMergePatch(patchStr); err != nil {
+			return fmt.Errorf("invalid merge-patch annotation: %w", err)
+		}
+	}
+	if patchStr, exists := annotations[StrategicMergePatchAnnotation]; exists {
+		if err := ValidateStrategicMergePatch(patchStr); err != nil {
+			return fmt.Errorf("invalid strategic-merge-patch annotation: %w", err)
+		}
+	}
 
-		// Check security restrictions
-		if err := ValidatePatchSecurity(obj); err != nil {
+	// Check security restrictions
+	if len(patchAnnotations) > 0 {
+		if err := ValidatePatchSecurity(obj, policy); err != nil {
 			return err
 		}
 	}
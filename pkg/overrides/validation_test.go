@@ -19,6 +19,7 @@ package overrides
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -90,7 +91,7 @@ func TestValidatePatchSecurity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidatePatchSecurity(tt.obj)
+			err := ValidatePatchSecurity(tt.obj, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidatePatchSecurity() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -311,11 +312,71 @@ func TestValidateAnnotations(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid merge-patch annotation",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "KubeDescheduler",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							MergePatchAnnotation: `{"spec": {"deschedulingIntervalSeconds": 120}}`,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "merge-patch that is not an object",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "KubeDescheduler",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							MergePatchAnnotation: `[{"op": "add"}]`,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid merge-patch annotation",
+		},
+		{
+			name: "strategic-merge-patch on sensitive kind",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "MachineConfig",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							StrategicMergePatchAnnotation: `{"spec": {"config": {}}}`,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "strategic merge patches are not allowed on sensitive resource kind: MachineConfig",
+		},
+		{
+			name: "multiple patch formats",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "KubeDescheduler",
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							PatchAnnotation:      `[{"op": "replace", "path": "/spec/mode", "value": "Automatic"}]`,
+							MergePatchAnnotation: `{"spec": {"mode": "Predictive"}}`,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "only one patch annotation may be set",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateAnnotations(tt.obj)
+			err := ValidateAnnotations(tt.obj, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateAnnotations() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -326,3 +387,34 @@ func TestValidateAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestPauseAnnotations(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	if _, ok := GetResumeAt(obj); ok {
+		t.Error("GetResumeAt() without annotation should report no auto-resume")
+	}
+	if episode, _ := GetPauseEpisode(obj); episode != 0 {
+		t.Errorf("GetPauseEpisode() without annotations = %d, want 0", episode)
+	}
+
+	obj.SetAnnotations(map[string]string{
+		AnnotationReconcilePaused:       "true",
+		AnnotationReconcilePausedAt:     "2026-01-01T02:00:00Z",
+		AnnotationReconcilePauseEpisode: "2",
+		AnnotationReconcileResumeAt:     "2026-01-01T02:30:00Z",
+	})
+
+	resumeAt, ok := GetResumeAt(obj)
+	if !ok || !resumeAt.Equal(time.Date(2026, 1, 1, 2, 30, 0, 0, time.UTC)) {
+		t.Errorf("GetResumeAt() = %v, %v", resumeAt, ok)
+	}
+	episode, pausedAt := GetPauseEpisode(obj)
+	if episode != 2 || !pausedAt.Equal(time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetPauseEpisode() = %d, %v", episode, pausedAt)
+	}
+
+	obj.SetAnnotations(map[string]string{AnnotationReconcileResumeAt: "tomorrow"})
+	if _, ok := GetResumeAt(obj); ok {
+		t.Error("GetResumeAt() with malformed annotation should report no auto-resume")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttling
+
+import (
+	"time"
+)
+
+const (
+	// DefaultAutoResumeMaxEpisodes is the number of auto-resumed pause episodes before a pause becomes permanent
+	DefaultAutoResumeMaxEpisodes = 3
+
+	// EpisodeResetAfter is how long a resource must stay quiet after an auto-resume
+	// before its next pause starts a new series of episodes
+	EpisodeResetAfter = 24 * time.Hour
+)
+
+// AutoResumePolicy controls the optional, time-boxed resume of resources paused by the ThrashingDetector.
+//
+// Every pause of a resource is an episode. Episode n is resumed automatically after
+// Window * 2^(n-1), so a one-off script that fought the autopilot overnight doesn't leave
+// the resource paused until someone notices. A resource that keeps thrashing after
+// MaxEpisodes auto-resumes is paused permanently, and needs a human like before.
+//
+// The zero value disables auto-resume: every pause is permanent.
+type AutoResumePolicy struct {
+	// Window is the cool-down of the first episode, 0 disables auto-resume
+	Window time.Duration
+
+	// MaxEpisodes is the number of episodes resumed automatically before escalating
+	MaxEpisodes int
+}
+
+// Enabled reports whether paused resources are resumed automatically
+func (p AutoResumePolicy) Enabled() bool {
+	return p.Window > 0
+}
+
+// CoolDown returns how long an episode (1-based) stays paused before it is resumed automatically
+// Returns false if the episode is a permanent pause.
+func (p AutoResumePolicy) CoolDown(episode int) (time.Duration, bool) {
+	maxEpisodes := p.MaxEpisodes
+	if maxEpisodes < 1 {
+		maxEpisodes = DefaultAutoResumeMaxEpisodes
+	}
+	if !p.Enabled() || episode < 1 || episode > maxEpisodes {
+		return 0, false
+	}
+	return p.Window * time.Duration(1<<(episode-1)), true
+}
+
+// NextEpisode returns the episode number of a new pause, given the previous episode and when it started
+// The count restarts when the resource stayed quiet for EpisodeResetAfter after the previous auto-resume.
+func (p AutoResumePolicy) NextEpisode(previous int, previousPausedAt, now time.Time) int {
+	if previous < 1 || previousPausedAt.IsZero() {
+		return 1
+	}
+
+	quietSince := previousPausedAt
+	if coolDown, ok := p.CoolDown(previous); ok {
+		quietSince = quietSince.Add(coolDown)
+	}
+	if now.Sub(quietSince) > EpisodeResetAfter {
+		return 1
+	}
+	return previous + 1
+}
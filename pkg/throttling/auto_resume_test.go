@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttling
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoResumePolicy_Disabled(t *testing.T) {
+	var policy AutoResumePolicy
+	if policy.Enabled() {
+		t.Error("zero policy should be disabled")
+	}
+	if _, ok := policy.CoolDown(1); ok {
+		t.Error("disabled policy should make every pause permanent")
+	}
+}
+
+func TestAutoResumePolicy_CoolDown(t *testing.T) {
+	policy := AutoResumePolicy{Window: 15 * time.Minute, MaxEpisodes: 3}
+
+	tests := []struct {
+		episode  int
+		want     time.Duration
+		wantAuto bool
+	}{
+		{episode: 1, want: 15 * time.Minute, wantAuto: true},
+		{episode: 2, want: 30 * time.Minute, wantAuto: true},
+		{episode: 3, want: time.Hour, wantAuto: true},
+		{episode: 4, wantAuto: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := policy.CoolDown(tt.episode)
+		if ok != tt.wantAuto || got != tt.want {
+			t.Errorf("CoolDown(%d) = %v, %v; want %v, %v", tt.episode, got, ok, tt.want, tt.wantAuto)
+		}
+	}
+
+	// MaxEpisodes defaults when unset
+	if _, ok := (AutoResumePolicy{Window: time.Minute}).CoolDown(DefaultAutoResumeMaxEpisodes); !ok {
+		t.Errorf("episode %d should auto-resume with default MaxEpisodes", DefaultAutoResumeMaxEpisodes)
+	}
+}
+
+func TestAutoResumePolicy_NextEpisode(t *testing.T) {
+	policy := AutoResumePolicy{Window: time.Hour, MaxEpisodes: 3}
+	pausedAt := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	if got := policy.NextEpisode(0, time.Time{}, pausedAt); got != 1 {
+		t.Errorf("first pause episode = %d, want 1", got)
+	}
+
+	// Episode 2 resumed after 2h; thrashing again soon after escalates
+	if got := policy.NextEpisode(2, pausedAt, pausedAt.Add(3*time.Hour)); got != 3 {
+		t.Errorf("repeated pause episode = %d, want 3", got)
+	}
+
+	// Quiet for more than EpisodeResetAfter after the auto-resume starts over
+	if got := policy.NextEpisode(2, pausedAt, pausedAt.Add(2*time.Hour+EpisodeResetAfter+time.Minute)); got != 1 {
+		t.Errorf("pause after a quiet period episode = %d, want 1", got)
+	}
+}
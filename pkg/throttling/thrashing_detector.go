@@ -35,12 +35,16 @@ const (
 // 3. Stop reconciliation until user removes annotation
 // 4. Self-documenting: Git history shows what happened and when
 //
-// Why not exponential backoff?
+// Why not plain exponential backoff?
 // - Backoff delays the problem, doesn't solve it
 // - Alerts still flap (metric increments on every retry)
 // - Wastes reconciliation cycles
 // - State lost on operator restart
 //
+// An AutoResumePolicy can still time-box the pause: the cool-down and episode count
+// live in annotations next to the pause annotation, and repeated episodes end in a
+// permanent pause, so the benefits below are kept.
+//
 // Benefits of pause-with-annotation:
 // - Zero wasted cycles (reconciliation fully stopped)
 // - Stable metrics and alerts (one increment, stays high)
@@ -56,6 +60,9 @@ type thrashingState struct {
 	// consecutiveThrottles counts how many times we've been throttled in a row
 	consecutiveThrottles int
 
+	// threshold is the number of consecutive throttles that triggers a pause
+	threshold int
+
 	// metricEmitted tracks if we've already incremented the metric
 	// We only increment once when threshold is reached
 	metricEmitted bool
@@ -71,6 +78,16 @@ func NewThrashingDetector() *ThrashingDetector {
 // RecordThrottle records that a resource was throttled by the token bucket.
 // Returns true if the resource should be paused (threshold reached).
 func (td *ThrashingDetector) RecordThrottle(key string) bool {
+	return td.RecordThrottleWithThreshold(key, ThrashingThreshold)
+}
+
+// RecordThrottleWithThreshold is RecordThrottle with a per-resource threshold.
+// A non-positive threshold falls back to ThrashingThreshold.
+func (td *ThrashingDetector) RecordThrottleWithThreshold(key string, threshold int) bool {
+	if threshold <= 0 {
+		threshold = ThrashingThreshold
+	}
+
 	td.mu.Lock()
 	defer td.mu.Unlock()
 
@@ -79,19 +96,21 @@ func (td *ThrashingDetector) RecordThrottle(key string) bool {
 		// First throttle for this resource
 		state = &thrashingState{
 			consecutiveThrottles: 1,
+			threshold:            threshold,
 			metricEmitted:        false,
 		}
 		td.states[key] = state
 
-		// Check if we've already hit threshold (shouldn't happen on first throttle)
-		return state.consecutiveThrottles >= ThrashingThreshold
+		// A threshold of 1 pauses on the first throttle
+		return state.consecutiveThrottles >= state.threshold
 	}
 
 	// Increment throttle count
 	state.consecutiveThrottles++
+	state.threshold = threshold
 
 	// Check if we've reached the threshold
-	return state.consecutiveThrottles >= ThrashingThreshold
+	return state.consecutiveThrottles >= state.threshold
 }
 
 // RecordSuccess records that a resource was successfully reconciled.
@@ -116,7 +135,7 @@ func (td *ThrashingDetector) ShouldEmitMetric(key string) bool {
 	}
 
 	// Only emit if we've reached threshold and haven't emitted yet
-	if state.consecutiveThrottles >= ThrashingThreshold && !state.metricEmitted {
+	if state.consecutiveThrottles >= state.threshold && !state.metricEmitted {
 		state.metricEmitted = true
 		return true
 	}
@@ -136,6 +155,47 @@ func (td *ThrashingDetector) GetAttempts(key string) int {
 	return state.consecutiveThrottles
 }
 
+// ThrashingSnapshot is the persistable thrashing state of a single resource
+type ThrashingSnapshot struct {
+	ConsecutiveThrottles int  `json:"consecutiveThrottles"`
+	Threshold            int  `json:"threshold,omitempty"`
+	MetricEmitted        bool `json:"metricEmitted,omitempty"`
+}
+
+// Snapshot returns the thrashing state of all tracked resources
+func (td *ThrashingDetector) Snapshot() map[string]ThrashingSnapshot {
+	td.mu.RLock()
+	defer td.mu.RUnlock()
+
+	snapshot := make(map[string]ThrashingSnapshot, len(td.states))
+	for key, state := range td.states {
+		snapshot[key] = ThrashingSnapshot{
+			ConsecutiveThrottles: state.consecutiveThrottles,
+			Threshold:            state.threshold,
+			MetricEmitted:        state.metricEmitted,
+		}
+	}
+	return snapshot
+}
+
+// Restore loads thrashing state saved by Snapshot, replacing the state of the same keys
+func (td *ThrashingDetector) Restore(snapshot map[string]ThrashingSnapshot) {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	for key, state := range snapshot {
+		threshold := state.Threshold
+		if threshold <= 0 {
+			threshold = ThrashingThreshold
+		}
+		td.states[key] = &thrashingState{
+			consecutiveThrottles: state.ConsecutiveThrottles,
+			threshold:            threshold,
+			metricEmitted:        state.MetricEmitted,
+		}
+	}
+}
+
 // Reset clears the thrashing state for a specific resource
 // Useful for testing or manual intervention
 func (td *ThrashingDetector) Reset(key string) {
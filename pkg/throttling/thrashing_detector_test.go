@@ -61,6 +61,42 @@ func TestThrashingDetector_ThresholdReached(t *testing.T) {
 	}
 }
 
+func TestThrashingDetector_CustomThreshold(t *testing.T) {
+	td := NewThrashingDetector()
+	key := "MachineConfig/50-swap-enable"
+
+	if td.RecordThrottleWithThreshold(key, 2) {
+		t.Error("first throttle should not trigger pause with threshold 2")
+	}
+	if !td.RecordThrottleWithThreshold(key, 2) {
+		t.Error("second throttle should trigger pause with threshold 2")
+	}
+	if !td.ShouldEmitMetric(key) {
+		t.Error("metric should be emitted once the custom threshold is reached")
+	}
+
+	// Non-positive thresholds fall back to the default
+	other := "default/test-cm/ConfigMap"
+	for i := 0; i < ThrashingThreshold-1; i++ {
+		if td.RecordThrottleWithThreshold(other, 0) {
+			t.Errorf("throttle %d should not trigger pause with default threshold", i+1)
+		}
+	}
+	if !td.RecordThrottleWithThreshold(other, 0) {
+		t.Error("default threshold should trigger pause")
+	}
+
+	// The threshold survives a snapshot/restore
+	restored := NewThrashingDetector()
+	restored.Restore(map[string]ThrashingSnapshot{key: {ConsecutiveThrottles: 1, Threshold: 2}})
+	if restored.ShouldEmitMetric(key) {
+		t.Error("restored state should not be past the threshold yet")
+	}
+	if !restored.RecordThrottleWithThreshold(key, 2) || !restored.ShouldEmitMetric(key) {
+		t.Error("restored threshold should apply to the next throttle")
+	}
+}
+
 func TestThrashingDetector_SuccessReset(t *testing.T) {
 	td := NewThrashingDetector()
 	key := "default/test-cm/ConfigMap"
@@ -314,3 +350,26 @@ func TestThrashingDetector_NoStateForUnknownResource(t *testing.T) {
 	// RecordSuccess of unknown resource should not panic
 	td.RecordSuccess(unknownKey) // Should be no-op
 }
+
+func TestThrashingDetector_SnapshotRestore(t *testing.T) {
+	td := NewThrashingDetector()
+	key := "default/test-cm/ConfigMap"
+	for i := 0; i < ThrashingThreshold; i++ {
+		td.RecordThrottle(key)
+	}
+	td.ShouldEmitMetric(key)
+
+	// A fresh detector (e.g. after a restart) continues the edit war instead of starting over
+	restored := NewThrashingDetector()
+	restored.Restore(td.Snapshot())
+
+	if attempts := restored.GetAttempts(key); attempts != ThrashingThreshold {
+		t.Errorf("expected %d attempts after restore, got %d", ThrashingThreshold, attempts)
+	}
+	if restored.ShouldEmitMetric(key) {
+		t.Error("metric was already emitted before the restart, should not emit again")
+	}
+	if !restored.RecordThrottle(key) {
+		t.Error("restored resource should still be paused")
+	}
+}
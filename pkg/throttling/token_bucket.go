@@ -45,6 +45,8 @@ type TokenBucket struct {
 // bucket represents a single token bucket for a resource
 type bucket struct {
 	tokens       int
+	capacity     int
+	window       time.Duration
 	lastFill     time.Time
 	lastAccessed time.Time // Track when bucket was last used for TTL cleanup
 }
@@ -66,6 +68,16 @@ func NewTokenBucketWithSettings(capacity int, window time.Duration) *TokenBucket
 // Allow checks if an operation is allowed for the given key
 // Returns true if operation is allowed, false if throttled
 func (tb *TokenBucket) Allow(key string) bool {
+	return tb.AllowWithSettings(key, tb.capacity, tb.window)
+}
+
+// AllowWithSettings checks if an operation is allowed for the given key using
+// a per-key capacity and window instead of the bucket defaults.
+// Non-positive values fall back to the defaults. A change of settings takes
+// effect immediately; tokens already spent in the current window still count.
+func (tb *TokenBucket) AllowWithSettings(key string, capacity int, window time.Duration) bool {
+	capacity, window = tb.settings(capacity, window)
+
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
@@ -74,7 +86,9 @@ func (tb *TokenBucket) Allow(key string) bool {
 	if !exists {
 		// First request for this key - create bucket with full capacity
 		tb.buckets[key] = &bucket{
-			tokens:       tb.capacity - 1, // Consume one token
+			tokens:       capacity - 1, // Consume one token
+			capacity:     capacity,
+			window:       window,
 			lastFill:     now,
 			lastAccessed: now,
 		}
@@ -84,9 +98,16 @@ func (tb *TokenBucket) Allow(key string) bool {
 	// Update last accessed time
 	b.lastAccessed = now
 
+	// Apply changed settings, keeping the tokens already spent in this window
+	if b.capacity != capacity {
+		b.tokens = capacity - (b.capacity - b.tokens)
+		b.capacity = capacity
+	}
+	b.window = window
+
 	// Check if window has expired and refill bucket
-	if now.Sub(b.lastFill) >= tb.window {
-		b.tokens = tb.capacity
+	if now.Sub(b.lastFill) >= b.window {
+		b.tokens = b.capacity
 		b.lastFill = now
 	}
 
@@ -103,16 +124,34 @@ func (tb *TokenBucket) Allow(key string) bool {
 // Record records an update for the given key
 // This is an alias for Allow for semantic clarity
 func (tb *TokenBucket) Record(key string) error {
-	if !tb.Allow(key) {
+	return tb.RecordWithSettings(key, tb.capacity, tb.window)
+}
+
+// RecordWithSettings records an update for the given key using a per-key
+// capacity and window (see AllowWithSettings)
+func (tb *TokenBucket) RecordWithSettings(key string, capacity int, window time.Duration) error {
+	capacity, window = tb.settings(capacity, window)
+	if !tb.AllowWithSettings(key, capacity, window) {
 		return &ThrottledError{
 			Key:      key,
-			Capacity: tb.capacity,
-			Window:   tb.window,
+			Capacity: capacity,
+			Window:   window,
 		}
 	}
 	return nil
 }
 
+// settings replaces non-positive per-key settings with the bucket defaults
+func (tb *TokenBucket) settings(capacity int, window time.Duration) (int, time.Duration) {
+	if capacity <= 0 {
+		capacity = tb.capacity
+	}
+	if window <= 0 {
+		window = tb.window
+	}
+	return capacity, window
+}
+
 // Reset resets the bucket for a given key
 func (tb *TokenBucket) Reset(key string) {
 	tb.mu.Lock()
@@ -140,8 +179,8 @@ func (tb *TokenBucket) GetTokens(key string) int {
 	}
 
 	// Check if window expired
-	if time.Since(b.lastFill) >= tb.window {
-		return tb.capacity
+	if time.Since(b.lastFill) >= b.window {
+		return b.capacity
 	}
 
 	return b.tokens
@@ -167,6 +206,55 @@ func (tb *TokenBucket) CleanupStale(ttl time.Duration) int {
 	return removed
 }
 
+// BucketSnapshot is the persistable state of a single token bucket
+type BucketSnapshot struct {
+	Tokens   int           `json:"tokens"`
+	Capacity int           `json:"capacity,omitempty"`
+	Window   time.Duration `json:"window,omitempty"`
+	LastFill time.Time     `json:"lastFill"`
+}
+
+// Snapshot returns the state of all buckets that still restrict updates
+// Buckets whose window expired are full again, so they are left out.
+func (tb *TokenBucket) Snapshot() map[string]BucketSnapshot {
+	tb.mu.RLock()
+	defer tb.mu.RUnlock()
+
+	now := time.Now()
+	snapshot := make(map[string]BucketSnapshot)
+	for key, b := range tb.buckets {
+		if now.Sub(b.lastFill) >= b.window {
+			continue
+		}
+		snapshot[key] = BucketSnapshot{
+			Tokens:   b.tokens,
+			Capacity: b.capacity,
+			Window:   b.window,
+			LastFill: b.lastFill,
+		}
+	}
+	return snapshot
+}
+
+// Restore loads bucket state saved by Snapshot, replacing the state of the same keys
+// Snapshots written without per-key settings get the bucket defaults.
+func (tb *TokenBucket) Restore(snapshot map[string]BucketSnapshot) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+	for key, state := range snapshot {
+		capacity, window := tb.settings(state.Capacity, state.Window)
+		tb.buckets[key] = &bucket{
+			tokens:       state.Tokens,
+			capacity:     capacity,
+			window:       window,
+			lastFill:     state.LastFill,
+			lastAccessed: now,
+		}
+	}
+}
+
 // ThrottledError is returned when an operation is throttled
 type ThrottledError struct {
 	Key      string
@@ -17,6 +17,8 @@ limitations under the License.
 package throttling
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -127,6 +129,67 @@ func TestTokenBucket_Record(t *testing.T) {
 	})
 }
 
+func TestTokenBucket_WithSettings(t *testing.T) {
+	t.Run("per-key settings override defaults", func(t *testing.T) {
+		tb := NewTokenBucket()
+
+		if err := tb.RecordWithSettings("machine-config", 1, time.Hour); err != nil {
+			t.Fatalf("first update should be allowed, got %v", err)
+		}
+		err := tb.RecordWithSettings("machine-config", 1, time.Hour)
+		throttledErr, ok := err.(*ThrottledError)
+		if !ok {
+			t.Fatalf("expected *ThrottledError, got %v", err)
+		}
+		if throttledErr.Capacity != 1 || throttledErr.Window != time.Hour {
+			t.Errorf("error should report per-key settings, got %d per %s", throttledErr.Capacity, throttledErr.Window)
+		}
+
+		// Other keys keep the default capacity
+		for i := 0; i < DefaultCapacity; i++ {
+			if !tb.Allow("config-map") {
+				t.Errorf("request %d should be allowed with default capacity", i+1)
+			}
+		}
+	})
+
+	t.Run("non-positive settings fall back to defaults", func(t *testing.T) {
+		tb := NewTokenBucketWithSettings(2, time.Minute)
+
+		err := tb.RecordWithSettings("key", 0, 0)
+		if err != nil {
+			t.Fatalf("first update should be allowed, got %v", err)
+		}
+		if tokens := tb.GetTokens("key"); tokens != 1 {
+			t.Errorf("expected 1 token left with default capacity, got %d", tokens)
+		}
+	})
+
+	t.Run("lowering capacity keeps spent tokens", func(t *testing.T) {
+		tb := NewTokenBucketWithSettings(5, time.Minute)
+		tb.Allow("key")
+		tb.Allow("key")
+
+		if !tb.AllowWithSettings("key", 3, time.Minute) {
+			t.Error("third update should fit in capacity 3")
+		}
+		if tb.AllowWithSettings("key", 3, time.Minute) {
+			t.Error("fourth update should be throttled with capacity 3")
+		}
+	})
+
+	t.Run("snapshot keeps per-key settings", func(t *testing.T) {
+		tb := NewTokenBucket()
+		tb.AllowWithSettings("key", 1, time.Hour)
+
+		restored := NewTokenBucket()
+		restored.Restore(tb.Snapshot())
+		if tokens := restored.GetTokens("key"); tokens != 0 {
+			t.Errorf("restored bucket should still be exhausted for its own window, got %d tokens", tokens)
+		}
+	})
+}
+
 func TestTokenBucket_Reset(t *testing.T) {
 	t.Run("resets specific key", func(t *testing.T) {
 		tb := NewTokenBucketWithSettings(1, 1*time.Minute)
@@ -324,6 +387,34 @@ func BenchmarkTokenBucket_Allow(b *testing.B) {
 	}
 }
 
+func TestTokenBucket_ConcurrentAccess(t *testing.T) {
+	tb := NewTokenBucket()
+	capacity := 20
+
+	// Assets of one dependency level are reconciled in parallel, each with its own key and limits
+	var wg sync.WaitGroup
+	for i := range 10 {
+		key := fmt.Sprintf("ns/asset-%d/ConfigMap", i%5)
+		wg.Go(func() {
+			for range 2 {
+				if err := tb.RecordWithSettings(key, capacity, time.Minute); err != nil {
+					t.Errorf("%s: unexpected throttle: %v", key, err)
+				}
+			}
+			tb.CleanupStale(DefaultTTL)
+		})
+	}
+	wg.Wait()
+
+	// Two workers per key, two updates each
+	for i := range 5 {
+		key := fmt.Sprintf("ns/asset-%d/ConfigMap", i)
+		if got := tb.GetTokens(key); got != capacity-4 {
+			t.Errorf("%s: expected %d tokens, got %d", key, capacity-4, got)
+		}
+	}
+}
+
 func BenchmarkTokenBucket_ConcurrentAccess(b *testing.B) {
 	tb := NewTokenBucket()
 	b.ResetTimer()
@@ -425,3 +516,34 @@ func TestTokenBucket_CleanupStale(t *testing.T) {
 		}
 	})
 }
+
+func TestTokenBucket_SnapshotRestore(t *testing.T) {
+	tb := NewTokenBucketWithSettings(3, time.Minute)
+	tb.Allow("busy-key")
+	tb.Allow("busy-key")
+
+	expired := NewTokenBucketWithSettings(3, time.Millisecond)
+	expired.Allow("idle-key")
+	time.Sleep(5 * time.Millisecond)
+	if snapshot := expired.Snapshot(); len(snapshot) != 0 {
+		t.Errorf("expired buckets should not be snapshotted, got %v", snapshot)
+	}
+
+	snapshot := tb.Snapshot()
+	if snapshot["busy-key"].Tokens != 1 {
+		t.Fatalf("expected 1 token in snapshot, got %+v", snapshot["busy-key"])
+	}
+
+	// A fresh bucket (e.g. after a restart) keeps limiting the restored key
+	restored := NewTokenBucketWithSettings(3, time.Minute)
+	restored.Restore(snapshot)
+	if tokens := restored.GetTokens("busy-key"); tokens != 1 {
+		t.Errorf("expected 1 token after restore, got %d", tokens)
+	}
+	if !restored.Allow("busy-key") {
+		t.Error("last token should be allowed")
+	}
+	if restored.Allow("busy-key") {
+		t.Error("restored bucket should be exhausted")
+	}
+}
@@ -35,17 +35,20 @@ import (
 // ComponentKindMapping maps asset components to their CRD names
 // This defines the soft dependencies - which components require which CRDs
 var ComponentKindMapping = map[string]string{
-	"MachineConfig":          "machineconfigs.machineconfiguration.openshift.io",
-	"KubeletConfig":          "kubeletconfigs.machineconfiguration.openshift.io",
-	"NodeHealthCheck":        "nodehealthchecks.remediation.medik8s.io",
-	"ForkliftController":     "forkliftcontrollers.forklift.konveyor.io",
-	"MetalLB":                "metallbs.metallb.io",
-	"UIPlugin":               "uiplugins.console.openshift.io",
-	"KubeDescheduler":        "kubedeschedulers.operator.openshift.io",
-	"PrometheusRule":         "prometheusrules.monitoring.coreos.com",
-	"SelfNodeRemediation":    "selfnoderemediations.self-node-remediation.medik8s.io",
-	"FenceAgentsRemediation": "fenceagentsremediations.fence-agents-remediation.medik8s.io",
-	"HyperConverged":         "hyperconvergeds.hco.kubevirt.io", // Always required
+	"MachineConfig":                  "machineconfigs.machineconfiguration.openshift.io",
+	"KubeletConfig":                  "kubeletconfigs.machineconfiguration.openshift.io",
+	"NodeHealthCheck":                "nodehealthchecks.remediation.medik8s.io",
+	"ForkliftController":             "forkliftcontrollers.forklift.konveyor.io",
+	"MetalLB":                        "metallbs.metallb.io",
+	"UIPlugin":                       "uiplugins.console.openshift.io",
+	"KubeDescheduler":                "kubedeschedulers.operator.openshift.io",
+	"PrometheusRule":                 "prometheusrules.monitoring.coreos.com",
+	"SelfNodeRemediation":            "selfnoderemediations.self-node-remediation.medik8s.io",
+	"FenceAgentsRemediation":         "fenceagentsremediations.fence-agents-remediation.medik8s.io",
+	"FenceAgentsRemediationTemplate": "fenceagentsremediationtemplates.fence-agents-remediation.medik8s.io",
+	"NMState":                        "nmstates.nmstate.io",
+	"DataProtectionApplication":      "dataprotectionapplications.oadp.openshift.io",
+	"HyperConverged":                 "hyperconvergeds.hco.kubevirt.io", // Always required
 }
 
 // CRDChecker provides CRD availability checking with caching
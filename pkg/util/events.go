@@ -17,6 +17,9 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
@@ -39,11 +42,16 @@ const (
 	EventReasonPatchApplied       = "PatchApplied"
 	EventReasonReconcileSucceeded = "ReconcileSucceeded"
 	EventReasonCRDDiscovered      = "CRDDiscovered"
+	EventReasonReconcileResumed   = "ReconcileResumed"
+	EventReasonObjectPruned       = "ObjectPruned"
 
 	// Informational events
 	EventReasonAssetSkipped    = "AssetSkipped"
 	EventReasonNoDriftDetected = "NoDriftDetected"
 	EventReasonUnmanagedMode   = "UnmanagedMode"
+	EventReasonRolloutDeferred = "RolloutDeferred"
+	EventReasonDrainDeferred   = "NodeMaintenanceDeferred"
+	EventReasonOutsideWindow   = "OutsideMaintenanceWindow"
 
 	// Warning events
 	EventReasonDriftDetected           = "DriftDetected"
@@ -55,6 +63,14 @@ const (
 	EventReasonApplyFailed             = "ApplyFailed"
 	EventReasonRenderFailed            = "RenderFailed"
 	EventReasonHardwareDetectionFailed = "HardwareDetectionFailed"
+	EventReasonPoolDegraded            = "MachineConfigPoolDegraded"
+	EventReasonInvalidWindow           = "InvalidMaintenanceWindow"
+	EventReasonInvalidOverrides        = "InvalidOverrides"
+	EventReasonInvalidPatchPolicy      = "InvalidPatchPolicy"
+
+	// Audit mode events
+	EventReasonAuditDrift     = "AuditDrift"
+	EventReasonAuditCompleted = "AuditCompleted"
 
 	// Tombstone events
 	EventReasonTombstoneDeleted = "TombstoneDeleted"
@@ -98,6 +114,12 @@ func (e *EventRecorder) PatchApplied(object runtime.Object, kind, namespace, nam
 		"Applied %d JSON patch operation(s) to %s/%s/%s", operations, kind, namespace, name)
 }
 
+// MergePatchApplied records that a user JSON merge patch or strategic merge patch was applied
+func (e *EventRecorder) MergePatchApplied(object runtime.Object, kind, namespace, name, format string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonPatchApplied, "PatchApplied",
+		"Applied %s to %s/%s/%s", format, kind, namespace, name)
+}
+
 // InvalidPatch records that a user's JSON patch was invalid
 func (e *EventRecorder) InvalidPatch(object runtime.Object, kind, namespace, name, reason string) {
 	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidPatch, "InvalidPatch",
@@ -117,17 +139,85 @@ func (e *EventRecorder) Throttled(object runtime.Object, kind, namespace, name s
 }
 
 // ThrashingDetected records that an edit war was detected and reconciliation was paused
-func (e *EventRecorder) ThrashingDetected(object runtime.Object, kind, namespace, name string, attempts int) {
+// competitors lists the competing field managers and their fields, empty if unknown
+// resumeAfter is the cool-down before the automatic resume, 0 for a permanent pause
+func (e *EventRecorder) ThrashingDetected(object runtime.Object, kind, namespace, name string, attempts int, competitors string, resumeAfter time.Duration) {
+	if competitors == "" {
+		competitors = "unknown"
+	}
+	pause := "Reconciliation paused until the annotation is removed"
+	if resumeAfter > 0 {
+		pause = fmt.Sprintf("Reconciliation paused, resuming automatically in %s", resumeAfter)
+	}
 	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonThrashingDetected, "ThrashingDetected",
 		"Edit war detected for %s/%s/%s after %d consecutive throttles. "+
-			"Reconciliation paused. Another actor is modifying this resource, "+
-			"conflicting with operator management. Remove annotation '%s=true' "+
+			"%s. Another actor is modifying this resource, "+
+			"conflicting with operator management (competing managers: %s). Remove annotation '%s=true' "+
 			"to resume, or set '%s=unmanaged' if external management is intentional.",
-		kind, namespace, name, attempts,
+		kind, namespace, name, attempts, pause, competitors,
 		"platform.kubevirt.io/reconcile-paused",
 		"platform.kubevirt.io/mode")
 }
 
+// ReconcileResumed records that a paused resource was resumed automatically after its cool-down
+func (e *EventRecorder) ReconcileResumed(object runtime.Object, kind, namespace, name string, episode int) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonReconcileResumed, "ReconcileResumed",
+		"Cool-down elapsed for %s/%s/%s, reconciliation resumed automatically (pause episode %d)",
+		kind, namespace, name, episode)
+}
+
+// RolloutDeferred records that a node-level change waits for a MachineConfigPool rollout to finish
+func (e *EventRecorder) RolloutDeferred(object runtime.Object, kind, name, pool string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonRolloutDeferred, "RolloutDeferred",
+		"Deferring %s/%s until MachineConfigPool %s finished updating", kind, name, pool)
+}
+
+// NodeMaintenanceDeferred records that a node-level change waits for Node Maintenance Operator drains
+func (e *EventRecorder) NodeMaintenanceDeferred(object runtime.Object, kind, name string, drains, limit int) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonDrainDeferred, "NodeMaintenanceDeferred",
+		"Deferring %s/%s while %d nodes are drained for maintenance (at most %d at once)", kind, name, drains, limit)
+}
+
+// OutsideMaintenanceWindow records that drift on a disruptive asset waits for the maintenance window
+func (e *EventRecorder) OutsideMaintenanceWindow(object runtime.Object, kind, namespace, name, nextWindow string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonOutsideWindow, "OutsideMaintenanceWindow",
+		"Drift on disruptive resource %s/%s/%s will be corrected in the next maintenance window (%s)",
+		kind, namespace, name, nextWindow)
+}
+
+// InvalidMaintenanceWindow records that the maintenance-window annotation could not be parsed
+func (e *EventRecorder) InvalidMaintenanceWindow(object runtime.Object, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidWindow, "InvalidMaintenanceWindow",
+		"Invalid maintenance-window annotation, holding back disruptive assets: %s", reason)
+}
+
+// InvalidOverrides records that the overrides ConfigMap referenced by the HCO can't be used
+func (e *EventRecorder) InvalidOverrides(object runtime.Object, configMap, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidOverrides, "InvalidOverrides",
+		"Invalid overrides ConfigMap %s: %s", configMap, reason)
+}
+
+// InvalidInitialOverrides records that the HCO's initial-overrides annotation can't be used
+func (e *EventRecorder) InvalidInitialOverrides(object runtime.Object, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidOverrides, "InvalidInitialOverrides",
+		"Invalid initial-overrides annotation: %s", reason)
+}
+
+// InvalidPatchPolicy records that the patch policy ConfigMap can't be used
+func (e *EventRecorder) InvalidPatchPolicy(object runtime.Object, configMap, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidPatchPolicy, "InvalidPatchPolicy",
+		"Invalid patch policy ConfigMap %s, blocking patches on sensitive kinds: %s", configMap, reason)
+}
+
+// MachineConfigPoolDegraded records that a degraded MachineConfigPool blocks node-level changes
+func (e *EventRecorder) MachineConfigPoolDegraded(object runtime.Object, pool, message string) {
+	if message == "" {
+		message = "no details reported"
+	}
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonPoolDegraded, "MachineConfigPoolDegraded",
+		"MachineConfigPool %s is degraded, holding back MachineConfig and KubeletConfig changes: %s", pool, message)
+}
+
 // AssetSkipped records that an asset was skipped (conditions not met)
 func (e *EventRecorder) AssetSkipped(object runtime.Object, assetName, reason string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonAssetSkipped, "AssetSkipped",
@@ -182,6 +272,25 @@ func (e *EventRecorder) HardwareDetectionFailed(object runtime.Object, reason st
 		"Hardware detection failed, using defaults: %s", reason)
 }
 
+// AuditDrift records drift that audit mode reports instead of correcting
+// action is "create" or "update", fields summarizes the differing field paths
+func (e *EventRecorder) AuditDrift(object runtime.Object, kind, namespace, name, action, fields string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonAuditDrift, "AuditDrift",
+		"Audit mode: %s/%s/%s would be %sd (fields: %s)", kind, namespace, name, action, fields)
+}
+
+// AuditCompleted records the outcome of an audit pass
+func (e *EventRecorder) AuditCompleted(object runtime.Object, driftedCount, totalCount int) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonAuditCompleted, "AuditCompleted",
+		"Audit completed: %d/%d assets drifted, no changes written", driftedCount, totalCount)
+}
+
+// ObjectPruned records that an object its asset no longer renders was deleted
+func (e *EventRecorder) ObjectPruned(object runtime.Object, kind, namespace, name, asset string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonObjectPruned, "ObjectPruned",
+		"Deleted %s/%s/%s, no longer rendered by asset %s", kind, namespace, name, asset)
+}
+
 // TombstoneDeleted records that a tombstoned resource was successfully deleted
 func (e *EventRecorder) TombstoneDeleted(object runtime.Object, kind, namespace, name, path string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonTombstoneDeleted, "TombstoneDeleted",
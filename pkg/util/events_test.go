@@ -18,7 +18,9 @@ package util
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -152,6 +154,89 @@ func TestEventRecorder_PatchApplied(t *testing.T) {
 	}
 }
 
+func TestEventRecorder_MergePatchApplied(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.MergePatchApplied(obj, "PrometheusRule", "openshift-cnv", "rules", "strategic merge patch")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if !strings.Contains(event.Message, "Applied strategic merge patch to PrometheusRule/openshift-cnv/rules") {
+		t.Errorf("Unexpected message %q", event.Message)
+	}
+
+	if event.EventType != EventTypeNormal {
+		t.Errorf("Expected normal event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonPatchApplied {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonPatchApplied, event.Reason)
+	}
+}
+
+func TestEventRecorder_InvalidOverrides(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.InvalidOverrides(obj, "platform-overrides", "unknown asset descheduler-typo")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+
+	if event.EventType != EventTypeWarning {
+		t.Errorf("Expected warning event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonInvalidOverrides {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonInvalidOverrides, event.Reason)
+	}
+}
+
+func TestEventRecorder_InvalidInitialOverrides(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.InvalidInitialOverrides(obj, "invalid initial overrides key \"MachineConfig\"")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+
+	if event.Reason != EventReasonInvalidOverrides {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonInvalidOverrides, event.Reason)
+	}
+	if !strings.Contains(event.Message, "initial-overrides") {
+		t.Errorf("Expected message to name the annotation, got %s", event.Message)
+	}
+}
+
+func TestEventRecorder_InvalidPatchPolicy(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.InvalidPatchPolicy(obj, "virt-platform-autopilot-patch-policy", "Deployment is not a sensitive resource kind")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+
+	if event.EventType != EventTypeWarning {
+		t.Errorf("Expected warning event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonInvalidPatchPolicy {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonInvalidPatchPolicy, event.Reason)
+	}
+}
+
 func TestEventRecorder_InvalidPatch(t *testing.T) {
 	fake := &FakeRecorder{}
 	recorder := NewEventRecorder(fake)
@@ -192,6 +277,103 @@ func TestEventRecorder_Throttled(t *testing.T) {
 	}
 }
 
+func TestEventRecorder_ThrashingDetected(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.ThrashingDetected(obj, "ConfigMap", "default", "app", 3, "argocd-controller (data.key)", 15*time.Minute)
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.Reason != EventReasonThrashingDetected {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonThrashingDetected, event.Reason)
+	}
+	if !strings.Contains(event.Message, "competing managers: argocd-controller (data.key)") {
+		t.Errorf("Expected message to name the competing manager, got %q", event.Message)
+	}
+	if !strings.Contains(event.Message, "resuming automatically in 15m0s") {
+		t.Errorf("Expected message to announce the automatic resume, got %q", event.Message)
+	}
+
+	recorder.ThrashingDetected(obj, "ConfigMap", "default", "app", 3, "", 0)
+	event = fake.LastEvent()
+	if !strings.Contains(event.Message, "competing managers: unknown") {
+		t.Errorf("Expected unknown competing manager, got %q", event.Message)
+	}
+	if !strings.Contains(event.Message, "paused until the annotation is removed") {
+		t.Errorf("Expected message to announce a permanent pause, got %q", event.Message)
+	}
+}
+
+func TestEventRecorder_ReconcileResumed(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.ReconcileResumed(obj, "ConfigMap", "default", "app", 2)
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.EventType != EventTypeNormal {
+		t.Errorf("Expected normal event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonReconcileResumed {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonReconcileResumed, event.Reason)
+	}
+}
+
+func TestEventRecorder_MachineConfigPoolDegraded(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.MachineConfigPoolDegraded(obj, "worker", "")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.EventType != EventTypeWarning {
+		t.Errorf("Expected warning event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonPoolDegraded {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonPoolDegraded, event.Reason)
+	}
+
+	recorder.RolloutDeferred(obj, "MachineConfig", "90-worker-swap-online", "worker")
+	if event := fake.LastEvent(); event == nil || event.Reason != EventReasonRolloutDeferred {
+		t.Errorf("Expected Reason=%s, got %+v", EventReasonRolloutDeferred, event)
+	}
+
+	recorder.NodeMaintenanceDeferred(obj, "MachineConfig", "90-worker-swap-online", 2, 2)
+	if event := fake.LastEvent(); event == nil || event.Reason != EventReasonDrainDeferred {
+		t.Errorf("Expected Reason=%s, got %+v", EventReasonDrainDeferred, event)
+	}
+}
+
+func TestEventRecorder_MaintenanceWindow(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.OutsideMaintenanceWindow(obj, "MachineConfig", "", "90-worker-swap-online", "2026-10-19T22:00:00Z")
+	event := fake.LastEvent()
+	if event == nil || event.EventType != EventTypeNormal || event.Reason != EventReasonOutsideWindow {
+		t.Errorf("Expected normal %s event, got %+v", EventReasonOutsideWindow, event)
+	}
+
+	recorder.InvalidMaintenanceWindow(obj, "expected 5 schedule fields and a duration")
+	event = fake.LastEvent()
+	if event == nil || event.EventType != EventTypeWarning || event.Reason != EventReasonInvalidWindow {
+		t.Errorf("Expected warning %s event, got %+v", EventReasonInvalidWindow, event)
+	}
+}
+
 func TestEventRecorder_UnmanagedMode(t *testing.T) {
 	fake := &FakeRecorder{}
 	recorder := NewEventRecorder(fake)
@@ -402,3 +584,68 @@ func TestEventRecorder_NoDriftDetected(t *testing.T) {
 		t.Errorf("Expected Reason=%s, got %s", EventReasonNoDriftDetected, event.Reason)
 	}
 }
+
+func TestEventRecorder_AuditDrift(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.AuditDrift(obj, "MachineConfig", "", "50-swap", "update", "spec.config")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.EventType != EventTypeWarning {
+		t.Errorf("Expected warning event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonAuditDrift {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonAuditDrift, event.Reason)
+	}
+	expected := "Audit mode: MachineConfig//50-swap would be updated (fields: spec.config)"
+	if event.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, event.Message)
+	}
+}
+
+func TestEventRecorder_AuditCompleted(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.AuditCompleted(obj, 2, 7)
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.Reason != EventReasonAuditCompleted {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonAuditCompleted, event.Reason)
+	}
+	if event.Message != "Audit completed: 2/7 assets drifted, no changes written" {
+		t.Errorf("Unexpected message %q", event.Message)
+	}
+}
+
+func TestEventRecorder_ObjectPruned(t *testing.T) {
+	fake := &FakeRecorder{}
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.ObjectPruned(obj, "MachineConfigPool", "", "virt-intel-32c2t-128gi", "kubelet-cpu-manager")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+
+	if event.EventType != EventTypeNormal {
+		t.Errorf("Expected normal event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonObjectPruned {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonObjectPruned, event.Reason)
+	}
+	if !strings.Contains(event.Message, "kubelet-cpu-manager") {
+		t.Errorf("Expected message to name the asset, got %s", event.Message)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// ValidatePath is the path the annotation validating webhook is served on
+const ValidatePath = "/validate-platform-annotations"
+
+// validatedAnnotations are the annotations checked at write time
+// An update is only validated if one of them changed, so that a bad annotation written
+// before the webhook was enabled doesn't block unrelated updates.
+var validatedAnnotations = append([]string{
+	overrides.AnnotationIgnoreFields,
+	overrides.AnnotationMode,
+	overrides.AnnotationInitialOverrides,
+	engine.DisabledResourcesAnnotation,
+	engine.MaintenanceWindowAnnotation,
+}, overrides.PatchAnnotations...)
+
+// AnnotationValidator rejects invalid platform.kubevirt.io annotations at write time
+// The same checks run at reconcile time, where a bad annotation only falls back to the
+// defaults with an event. Patches are also dry-run against the rendered baseline.
+type AnnotationValidator struct {
+	client         client.Client
+	apiReader      client.Reader
+	namespace      string
+	loader         *assets.Loader
+	registry       *assets.Registry
+	renderer       *engine.Renderer
+	contextBuilder *engine.RenderContextBuilder
+}
+
+var _ admission.Handler = &AnnotationValidator{}
+
+// NewAnnotationValidator creates the validator, reading the HCO from namespace
+// The apiReader reads the patch policy, whose namespace the manager doesn't cache.
+func NewAnnotationValidator(c client.Client, apiReader client.Reader, loader *assets.Loader, registry *assets.Registry, namespace string) *AnnotationValidator {
+	renderer := engine.NewRenderer(loader)
+	renderer.SetClient(c) // Templates may introspect CRDs and query objects
+	return &AnnotationValidator{
+		client:         c,
+		apiReader:      apiReader,
+		namespace:      namespace,
+		loader:         loader,
+		registry:       registry,
+		renderer:       renderer,
+		contextBuilder: engine.NewRenderContextBuilder(c),
+	}
+}
+
+// Handle validates the annotations of a created or updated object
+func (v *AnnotationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		old := &unstructured.Unstructured{}
+		if err := old.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !annotationsChanged(old, obj) {
+			return admission.Allowed("")
+		}
+	}
+
+	if err := v.validate(ctx, obj); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// annotationsChanged reports whether an update changes any validated annotation
+func annotationsChanged(old, obj *unstructured.Unstructured) bool {
+	oldAnnotations := old.GetAnnotations()
+	annotations := obj.GetAnnotations()
+	for _, annotation := range validatedAnnotations {
+		oldValue, oldExists := oldAnnotations[annotation]
+		value, exists := annotations[annotation]
+		if oldExists != exists || oldValue != value {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the annotations of obj the way reconciliation would use them
+func (v *AnnotationValidator) validate(ctx context.Context, obj *unstructured.Unstructured) error {
+	annotations := obj.GetAnnotations()
+	policy := v.patchPolicy(ctx)
+
+	// Patch formats, patch policy of sensitive kinds, ignore-fields pointers and mode
+	if err := overrides.ValidateAnnotations(obj, policy); err != nil {
+		return err
+	}
+
+	// HCO annotations
+	if annotation, exists := annotations[engine.DisabledResourcesAnnotation]; exists {
+		if _, err := engine.ParseDisabledResources(annotation); err != nil {
+			return err
+		}
+	}
+	if annotation, exists := annotations[engine.MaintenanceWindowAnnotation]; exists {
+		if _, err := engine.ParseMaintenanceWindow(annotation); err != nil {
+			return fmt.Errorf("invalid maintenance-window annotation: %w", err)
+		}
+	}
+	if annotation, exists := annotations[overrides.AnnotationInitialOverrides]; exists {
+		if err := validateInitialOverrides(annotation, policy); err != nil {
+			return err
+		}
+	}
+
+	for _, annotation := range overrides.PatchAnnotations {
+		if _, exists := annotations[annotation]; exists {
+			return v.dryRunPatch(ctx, obj)
+		}
+	}
+	return nil
+}
+
+// validateInitialOverrides checks every entry as if it were annotations on its object
+func validateInitialOverrides(annotation string, policy *overrides.PatchPolicy) error {
+	entries, err := overrides.ParseInitialOverrides(annotation)
+	if err != nil {
+		return err
+	}
+
+	for key, entry := range entries {
+		parts := strings.Split(key, "/")
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(parts[0])
+		obj.SetName(parts[len(parts)-1])
+		obj.SetAnnotations(entry.Annotations())
+		if err := overrides.ValidateAnnotations(obj, policy); err != nil {
+			return fmt.Errorf("invalid initial overrides for %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// patchPolicy reads the patch policy ConfigMap, like the controller does
+// Without a valid policy, patches on sensitive kinds are rejected.
+func (v *AnnotationValidator) patchPolicy(ctx context.Context) *overrides.PatchPolicy {
+	policy, err := engine.LoadPatchPolicy(ctx, v.apiReader)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Invalid patch policy, rejecting patches on sensitive kinds")
+		return nil
+	}
+	return policy
+}
+
+// dryRunPatch applies the patch annotation of obj to the baseline rendered for it
+// Only assets rendering the kind of obj are rendered, with the overrides ConfigMap merged in
+// like reconciliation does. Objects no asset renders (or whose baseline can't be rendered
+// right now) are not checked: the webhook must not block writes because of the autopilot's own state.
+func (v *AnnotationValidator) dryRunPatch(ctx context.Context, obj *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+
+	candidates := v.registry.ListAssetsRenderingKind(obj.GetKind())
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	hco, err := v.hco(ctx, obj)
+	if err != nil {
+		logger.V(1).Info("Skipping patch dry-run, no HCO", "error", err.Error())
+		return nil
+	}
+	renderCtx, err := v.contextBuilder.Build(ctx, hco)
+	if err != nil {
+		logger.V(1).Info("Skipping patch dry-run, no render context", "error", err.Error())
+		return nil
+	}
+	assetOverrides := v.assetOverrides(ctx, hco)
+
+	for i := range candidates {
+		assetMeta := &candidates[i]
+		rendered, err := v.renderer.RenderMultiAsset(assetMeta, renderCtx)
+		if err != nil {
+			continue
+		}
+
+		for _, desired := range rendered {
+			if desired.GetKind() != obj.GetKind() || desired.GetNamespace() != obj.GetNamespace() || desired.GetName() != obj.GetName() {
+				continue
+			}
+
+			// Annotations on obj win over the ConfigMap entry, as in reconciliation
+			source := obj.DeepCopy()
+			if configured, ok := assetOverrides[assetMeta.Name]; ok {
+				source.SetAnnotations(overrides.MergeOverrides(configured, source.GetAnnotations()))
+			}
+			if overrides.IsUnmanaged(source) {
+				return nil
+			}
+
+			desiredAnnotations := desired.GetAnnotations()
+			if desiredAnnotations == nil {
+				desiredAnnotations = make(map[string]string)
+			}
+			for _, annotation := range overrides.PatchAnnotations {
+				if patchStr, exists := source.GetAnnotations()[annotation]; exists {
+					desiredAnnotations[annotation] = patchStr
+				}
+			}
+			desired.SetAnnotations(desiredAnnotations)
+
+			if annotation, _, err := engine.ApplyUserPatch(v.loader, desired); err != nil {
+				return fmt.Errorf("%s does not apply to the baseline of asset %s: %w", overrides.PatchFormat(annotation), assetMeta.Name, err)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// hco returns the HCO the baseline of obj is rendered from
+// A write to the HCO itself is rendered with the new HCO.
+func (v *AnnotationValidator) hco(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if obj.GroupVersionKind().GroupKind() == pkgcontext.HCOGVK.GroupKind() {
+		return obj, nil
+	}
+
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := v.client.Get(ctx, types.NamespacedName{Name: pkgcontext.HCOName, Namespace: v.namespace}, hco); err != nil {
+		return nil, fmt.Errorf("failed to get HCO: %w", err)
+	}
+	return hco, nil
+}
+
+// assetOverrides reads the overrides ConfigMap referenced by the HCO, like the controller does
+// A missing or malformed ConfigMap is ignored by reconciliation, so it is here as well.
+func (v *AnnotationValidator) assetOverrides(ctx context.Context, hco *unstructured.Unstructured) map[string]overrides.AssetOverrides {
+	assetOverrides, err := engine.LoadAssetOverrides(ctx, v.client, hco, v.namespace)
+	if engine.IsInvalidOverrides(err) {
+		log.FromContext(ctx).V(1).Info("Ignoring invalid overrides ConfigMap", "configMap", err.(*engine.InvalidOverridesError).ConfigMap, "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read overrides ConfigMap")
+		return nil
+	}
+	return assetOverrides
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+const testNamespace = "openshift-cnv"
+
+func newValidator(t *testing.T, objs ...client.Object) *AnnotationValidator {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	return NewAnnotationValidator(c, c, loader, registry, testNamespace)
+}
+
+func newSwapMachineConfig(annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	obj.SetKind("MachineConfig")
+	obj.SetName("90-worker-swap-online")
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func newRequest(t *testing.T, operation admissionv1.Operation, obj, old *unstructured.Unstructured) admission.Request {
+	t.Helper()
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation}}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	req.Object = runtime.RawExtension{Raw: raw}
+	if old != nil {
+		if req.OldObject.Raw, err = old.MarshalJSON(); err != nil {
+			t.Fatalf("MarshalJSON() error = %v", err)
+		}
+	}
+	return req
+}
+
+func TestHandle(t *testing.T) {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, testNamespace)
+	validator := newValidator(t)
+
+	tests := []struct {
+		name    string
+		obj     *unstructured.Unstructured
+		allowed bool
+		reason  string
+	}{
+		{
+			name: "valid ignore-fields",
+			obj: func() *unstructured.Unstructured {
+				obj := hco.DeepCopy()
+				obj.SetAnnotations(map[string]string{overrides.AnnotationIgnoreFields: "/spec/featureGates"})
+				return obj
+			}(),
+			allowed: true,
+		},
+		{
+			name: "invalid JSON patch",
+			obj: func() *unstructured.Unstructured {
+				obj := hco.DeepCopy()
+				obj.SetAnnotations(map[string]string{overrides.PatchAnnotation: `[{"op": "replace"`})
+				return obj
+			}(),
+			reason: "invalid patch annotation",
+		},
+		{
+			name: "invalid ignore-fields pointer",
+			obj: func() *unstructured.Unstructured {
+				obj := hco.DeepCopy()
+				obj.SetAnnotations(map[string]string{overrides.AnnotationIgnoreFields: "spec/featureGates"})
+				return obj
+			}(),
+			reason: "invalid ignore-fields annotation",
+		},
+		{
+			name: "invalid disabled-resources",
+			obj: func() *unstructured.Unstructured {
+				obj := hco.DeepCopy()
+				obj.SetAnnotations(map[string]string{engine.DisabledResourcesAnnotation: "- kind: MachineConfig\n"})
+				return obj
+			}(),
+			reason: "name is required",
+		},
+		{
+			name: "initial overrides breaking the patch policy",
+			obj: func() *unstructured.Unstructured {
+				obj := hco.DeepCopy()
+				obj.SetAnnotations(map[string]string{overrides.AnnotationInitialOverrides: "MachineConfig/50-virt-numa:\n  merge-patch: '{\"spec\": {}}'\n"})
+				return obj
+			}(),
+			reason: "invalid initial overrides for MachineConfig/50-virt-numa",
+		},
+		{
+			name:   "patch on sensitive kind without policy",
+			obj:    newSwapMachineConfig(map[string]string{overrides.MergePatchAnnotation: `{"spec": {"kernelArguments": ["iommu=pt"]}}`}),
+			reason: "not allowed on sensitive resource kind",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Create, tt.obj, nil))
+			if resp.Allowed != tt.allowed {
+				t.Fatalf("Handle() allowed = %v, want %v (%v)", resp.Allowed, tt.allowed, resp.Result)
+			}
+			if tt.reason != "" && !strings.Contains(resp.Result.Message, tt.reason) {
+				t.Errorf("Handle() message = %q, want it to contain %q", resp.Result.Message, tt.reason)
+			}
+		})
+	}
+}
+
+func TestHandleUnchangedAnnotations(t *testing.T) {
+	validator := newValidator(t)
+
+	// A bad annotation written before the webhook was enabled doesn't block other updates
+	old := newSwapMachineConfig(map[string]string{overrides.PatchAnnotation: `not a patch`})
+	obj := old.DeepCopy()
+	obj.SetLabels(map[string]string{"example.io/team": "virt"})
+
+	if resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Update, obj, old)); !resp.Allowed {
+		t.Errorf("Handle() denied an update that leaves the annotations unchanged: %v", resp.Result)
+	}
+
+	obj.SetAnnotations(map[string]string{overrides.PatchAnnotation: `still not a patch`})
+	if resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Update, obj, old)); resp.Allowed {
+		t.Error("Handle() allowed an update changing the patch to another invalid one")
+	}
+}
+
+func TestHandlePatchDryRun(t *testing.T) {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, testNamespace)
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: overrides.PatchPolicyConfigMapName, Namespace: overrides.PatchPolicyNamespace},
+		Data:       map[string]string{"MachineConfig": "/spec/config/systemd/units/*/contents"},
+	}
+	validator := newValidator(t, hco, policy)
+
+	// The swap MachineConfig renders two systemd units
+	valid := newSwapMachineConfig(map[string]string{
+		overrides.PatchAnnotation: `[{"op": "replace", "path": "/spec/config/systemd/units/1/contents", "value": "[Unit]"}]`,
+	})
+	if resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Create, valid, nil)); !resp.Allowed {
+		t.Errorf("Handle() denied a patch applying to the baseline: %v", resp.Result)
+	}
+
+	missing := newSwapMachineConfig(map[string]string{
+		overrides.PatchAnnotation: `[{"op": "replace", "path": "/spec/config/systemd/units/5/contents", "value": "[Unit]"}]`,
+	})
+	resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Create, missing, nil))
+	if resp.Allowed {
+		t.Fatal("Handle() allowed a patch that doesn't apply to the baseline")
+	}
+	if !strings.Contains(resp.Result.Message, "baseline of asset swap-enable") {
+		t.Errorf("Handle() message = %q, want it to name the asset", resp.Result.Message)
+	}
+}
+
+func TestHandlePatchDryRunOverridesConfigMap(t *testing.T) {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, testNamespace)
+	hco.SetAnnotations(map[string]string{overrides.AnnotationOverridesConfigMap: "autopilot-overrides"})
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: overrides.PatchPolicyConfigMapName, Namespace: overrides.PatchPolicyNamespace},
+		Data:       map[string]string{"MachineConfig": "/spec/config/systemd/units/*/contents"},
+	}
+	assetOverrides := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "autopilot-overrides", Namespace: testNamespace},
+		Data:       map[string]string{"swap-enable": "mode: unmanaged\n"},
+	}
+	validator := newValidator(t, hco, policy, assetOverrides)
+
+	// Reconciliation never applies the patch of an unmanaged asset, so it isn't dry-run
+	missing := newSwapMachineConfig(map[string]string{
+		overrides.PatchAnnotation: `[{"op": "replace", "path": "/spec/config/systemd/units/5/contents", "value": "[Unit]"}]`,
+	})
+	if resp := validator.Handle(context.Background(), newRequest(t, admissionv1.Create, missing, nil)); !resp.Allowed {
+		t.Errorf("Handle() dry-ran the patch of an asset the overrides ConfigMap leaves unmanaged: %v", resp.Result)
+	}
+}
@@ -178,13 +178,13 @@ var _ = Describe("Anti-Thrashing Integration", func() {
 			cm.SetNamespace(testNs)
 
 			By("incrementing thrashing metric once")
-			observability.IncThrashing(cm)
+			observability.IncThrashing(cm, "")
 
 			By("verifying metric value")
 			expected := `
 				# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 				# TYPE virt_platform_thrashing_total counter
-				virt_platform_thrashing_total{kind="ConfigMap",name="test-cm",namespace="` + testNs + `"} 1
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="test-cm",namespace="` + testNs + `"} 1
 			`
 			Expect(testutil.CollectAndCompare(observability.ThrashingTotal, strings.NewReader(expected))).To(Succeed())
 
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+var _ = Describe("Audit Mode Integration", func() {
+	var (
+		testNs       string
+		patcher      *engine.Patcher
+		fakeRecorder *FakeEventRecorder
+		hcoAsset     *assets.AssetMetadata
+		hco          *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		testNs = "test-audit-" + randString()
+
+		ns := &unstructured.Unstructured{}
+		ns.SetGroupVersionKind(nsGVK)
+		ns.SetName(testNs)
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, ns)
+		})
+
+		loader := assets.NewLoader()
+		registry, err := assets.NewRegistry(loader)
+		Expect(err).NotTo(HaveOccurred())
+		hcoAsset, err = registry.GetAsset("hco-golden-config")
+		Expect(err).NotTo(HaveOccurred())
+
+		patcher = engine.NewPatcher(k8sClient, apiReader, loader)
+		fakeRecorder = &FakeEventRecorder{}
+		patcher.SetEventRecorder(util.NewEventRecorder(fakeRecorder))
+		patcher.SetMode(engine.ModeAudit)
+
+		hco = pkgcontext.NewMockHCO(pkgcontext.HCOName, testNs)
+	})
+
+	getHCO := func() *unstructured.Unstructured {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNs, Name: pkgcontext.HCOName}, live)).To(Succeed())
+		return live
+	}
+
+	auditEvents := func() []RecordedEvent {
+		var events []RecordedEvent
+		for _, event := range fakeRecorder.Events {
+			if event.Reason == util.EventReasonAuditDrift {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	It("should report a missing object without creating it", func() {
+		drifted, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: hco})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifted).To(BeTrue(), "Missing object should be reported as drift")
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		err = k8sClient.Get(ctx, client.ObjectKey{Namespace: testNs, Name: pkgcontext.HCOName}, live)
+		Expect(err).To(HaveOccurred(), "Audit mode must not create objects")
+
+		events := auditEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].EventType).To(Equal(util.EventTypeWarning))
+		Expect(events[0].Message).To(ContainSubstring("would be created"))
+	})
+
+	It("should report drift on an existing object without updating it", func() {
+		Expect(k8sClient.Create(ctx, hco)).To(Succeed())
+		before := getHCO()
+
+		drifted, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: before})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifted).To(BeTrue())
+
+		after := getHCO()
+		Expect(after.GetResourceVersion()).To(Equal(before.GetResourceVersion()), "Audit mode must not write")
+		_, found, _ := unstructured.NestedString(after.Object, "spec", "tuningPolicy")
+		Expect(found).To(BeFalse())
+
+		events := auditEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Message).To(ContainSubstring("would be updated"))
+		Expect(events[0].Message).To(ContainSubstring("spec."))
+	})
+
+	It("should report no drift once the object matches", func() {
+		Expect(k8sClient.Create(ctx, hco)).To(Succeed())
+
+		// Converge in enforce mode, then audit
+		patcher.SetMode(engine.ModeEnforce)
+		_, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: getHCO()})
+		Expect(err).NotTo(HaveOccurred())
+
+		patcher.SetMode(engine.ModeAudit)
+		fakeRecorder.Reset()
+		drifted, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: getHCO()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifted).To(BeFalse())
+		Expect(auditEvents()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+var _ = Describe("Auto-Resume Integration", func() {
+	var (
+		testNs       string
+		patcher      *engine.Patcher
+		fakeRecorder *FakeEventRecorder
+		hcoAsset     *assets.AssetMetadata
+		hco          *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		testNs = "test-auto-resume-" + randString()
+
+		ns := &unstructured.Unstructured{}
+		ns.SetGroupVersionKind(nsGVK)
+		ns.SetName(testNs)
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, ns)
+		})
+
+		loader := assets.NewLoader()
+		registry, err := assets.NewRegistry(loader)
+		Expect(err).NotTo(HaveOccurred())
+		hcoAsset, err = registry.GetAsset("hco-golden-config")
+		Expect(err).NotTo(HaveOccurred())
+
+		patcher = engine.NewPatcher(k8sClient, apiReader, loader)
+		fakeRecorder = &FakeEventRecorder{}
+		patcher.SetEventRecorder(util.NewEventRecorder(fakeRecorder))
+		patcher.SetAutoResumePolicy(throttling.AutoResumePolicy{Window: 15 * time.Minute, MaxEpisodes: 3})
+
+		hco = pkgcontext.NewMockHCO(pkgcontext.HCOName, testNs)
+	})
+
+	getHCO := func() *unstructured.Unstructured {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNs, Name: pkgcontext.HCOName}, live)).To(Succeed())
+		return live
+	}
+
+	pause := func(resumeAt time.Time) {
+		hco.SetAnnotations(map[string]string{
+			overrides.AnnotationReconcilePaused:       "true",
+			overrides.AnnotationReconcilePausedAt:     resumeAt.Add(-15 * time.Minute).UTC().Format(time.RFC3339),
+			overrides.AnnotationReconcilePauseEpisode: "1",
+			overrides.AnnotationReconcileResumeAt:     resumeAt.UTC().Format(time.RFC3339),
+		})
+		Expect(k8sClient.Create(ctx, hco)).To(Succeed())
+	}
+
+	It("should stay paused until the cool-down elapses", func() {
+		pause(time.Now().Add(10 * time.Minute))
+		before := getHCO()
+
+		applied, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: before})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeFalse())
+
+		after := getHCO()
+		Expect(after.GetResourceVersion()).To(Equal(before.GetResourceVersion()), "Paused object must not be written")
+		Expect(overrides.IsPaused(after)).To(BeTrue())
+	})
+
+	It("should resume and reconcile once the cool-down elapsed", func() {
+		pause(time.Now().Add(-time.Minute))
+
+		applied, err := patcher.ReconcileAsset(ctx, hcoAsset, &pkgcontext.RenderContext{HCO: getHCO()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+
+		after := getHCO()
+		Expect(overrides.IsPaused(after)).To(BeFalse())
+		Expect(after.GetAnnotations()).NotTo(HaveKey(overrides.AnnotationReconcileResumeAt))
+		episode, _ := overrides.GetPauseEpisode(after)
+		Expect(episode).To(Equal(1), "Episode is kept so a repeated pause escalates")
+
+		var resumed bool
+		for _, event := range fakeRecorder.Events {
+			if event.Reason == util.EventReasonReconcileResumed {
+				resumed = true
+			}
+		}
+		Expect(resumed).To(BeTrue(), "ReconcileResumed event should be recorded")
+	})
+})
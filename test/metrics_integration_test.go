@@ -223,15 +223,15 @@ var _ = Describe("Metrics Integration", func() {
 			}
 
 			By("incrementing thrashing counter 3 times")
-			observability.IncThrashing(cm)
-			observability.IncThrashing(cm)
-			observability.IncThrashing(cm)
+			observability.IncThrashing(cm, "")
+			observability.IncThrashing(cm, "")
+			observability.IncThrashing(cm, "")
 
 			By("verifying counter value is 3")
 			expected := `
 				# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 				# TYPE virt_platform_thrashing_total counter
-				virt_platform_thrashing_total{kind="ConfigMap",name="thrashing-cm",namespace="` + testNs + `"} 3
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="thrashing-cm",namespace="` + testNs + `"} 3
 			`
 			Expect(testutil.CollectAndCompare(observability.ThrashingTotal, strings.NewReader(expected))).To(Succeed())
 		})
@@ -248,18 +248,18 @@ var _ = Describe("Metrics Integration", func() {
 			cm2.SetNamespace(testNs)
 
 			By("incrementing thrashing for cm1 twice")
-			observability.IncThrashing(cm1)
-			observability.IncThrashing(cm1)
+			observability.IncThrashing(cm1, "")
+			observability.IncThrashing(cm1, "")
 
 			By("incrementing thrashing for cm2 once")
-			observability.IncThrashing(cm2)
+			observability.IncThrashing(cm2, "")
 
 			By("verifying independent counters")
 			expected := `
 				# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 				# TYPE virt_platform_thrashing_total counter
-				virt_platform_thrashing_total{kind="ConfigMap",name="cm1",namespace="` + testNs + `"} 2
-				virt_platform_thrashing_total{kind="ConfigMap",name="cm2",namespace="` + testNs + `"} 1
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="cm1",namespace="` + testNs + `"} 2
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="cm2",namespace="` + testNs + `"} 1
 			`
 			Expect(testutil.CollectAndCompare(observability.ThrashingTotal, strings.NewReader(expected))).To(Succeed())
 		})
@@ -271,25 +271,25 @@ var _ = Describe("Metrics Integration", func() {
 			cm.SetNamespace(testNs)
 
 			By("incrementing counter twice")
-			observability.IncThrashing(cm)
-			observability.IncThrashing(cm)
+			observability.IncThrashing(cm, "")
+			observability.IncThrashing(cm, "")
 
 			By("verifying counter is 2")
 			expected2 := `
 				# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 				# TYPE virt_platform_thrashing_total counter
-				virt_platform_thrashing_total{kind="ConfigMap",name="monotonic-cm",namespace="` + testNs + `"} 2
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="monotonic-cm",namespace="` + testNs + `"} 2
 			`
 			Expect(testutil.CollectAndCompare(observability.ThrashingTotal, strings.NewReader(expected2))).To(Succeed())
 
 			By("incrementing again")
-			observability.IncThrashing(cm)
+			observability.IncThrashing(cm, "")
 
 			By("verifying counter increases to 3 (monotonic - never decreases)")
 			expected3 := `
 				# HELP virt_platform_thrashing_total Total number of reconciliation throttling events (anti-thrashing gate hits)
 				# TYPE virt_platform_thrashing_total counter
-				virt_platform_thrashing_total{kind="ConfigMap",name="monotonic-cm",namespace="` + testNs + `"} 3
+				virt_platform_thrashing_total{kind="ConfigMap",manager="unknown",name="monotonic-cm",namespace="` + testNs + `"} 3
 			`
 			Expect(testutil.CollectAndCompare(observability.ThrashingTotal, strings.NewReader(expected3))).To(Succeed())
 		})
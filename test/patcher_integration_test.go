@@ -130,7 +130,7 @@ var _ = Describe("Patched Baseline Algorithm Integration", func() {
 
 			// Detect drift
 			driftDetector := engine.NewDriftDetector(k8sClient)
-			hasDrift, err := driftDetector.DetectDrift(ctx, obj, live)
+			hasDrift, _, err := driftDetector.DetectDrift(ctx, obj, live)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(hasDrift).To(BeTrue(), "Should detect drift when values differ")
 
@@ -506,7 +506,7 @@ var _ = Describe("Patched Baseline Algorithm Integration", func() {
 
 			// 4. Detect drift
 			driftDetector := engine.NewDriftDetector(k8sClient)
-			hasDrift, err := driftDetector.DetectDrift(ctx, masked, live)
+			hasDrift, _, err := driftDetector.DetectDrift(ctx, masked, live)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(hasDrift).To(BeTrue(), "Should detect drift due to operatorField change")
 